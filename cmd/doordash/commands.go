@@ -0,0 +1,109 @@
+package main
+
+import "github.com/alext251/doordash-go-sdk/doordash"
+
+type command struct {
+	// Number of positional arguments
+	args int
+	// Columns printed in table output for list results
+	columns []string
+	run     func(c *doordash.Client, args []string, opts *options) (interface{}, error)
+}
+
+var (
+	businessColumns = []string{"external_business_id", "name", "activation_status", "is_test"}
+	storeColumns    = []string{"external_store_id", "name", "address", "phone_number", "status"}
+)
+
+var commands = map[string]command{
+	"delivery create": {run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		body := &doordash.NewDelivery{}
+		if err := readBody(opts, body); err != nil {
+			return nil, err
+		}
+		return c.CreateDelivery(body)
+	}},
+	"delivery get": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.GetDeliveryStatus(args[0])
+	}},
+	"delivery update": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		fields, err := readFields(opts, &doordash.DeliveryUpdate{})
+		if err != nil {
+			return nil, err
+		}
+		res := &doordash.DeliveryInfo{}
+		return res, patch(c, "UpdateDelivery", "drive/v2/deliveries/"+args[0], fields, res)
+	}},
+	"delivery cancel": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.CancelDelivery(args[0])
+	}},
+
+	"quote create": {run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		body := &doordash.NewQuote{}
+		if err := readBody(opts, body); err != nil {
+			return nil, err
+		}
+		return c.CreateDeliveryQuote(body)
+	}},
+	"quote accept": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.AcceptDeliveryQuote(args[0])
+	}},
+
+	"business list": {columns: businessColumns, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.ListBusinesses(opts.status, "")
+	}},
+	"business get": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.GetBusiness(args[0])
+	}},
+	"business create": {run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		body := &doordash.NewBusiness{}
+		if err := readBody(opts, body); err != nil {
+			return nil, err
+		}
+		return c.CreateBusiness(body)
+	}},
+	"business update": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		fields, err := readFields(opts, &doordash.BusinessUpdate{})
+		if err != nil {
+			return nil, err
+		}
+		res := &doordash.BusinessInfo{}
+		return res, patch(c, "UpdateBusiness", "developer/v1/businesses/"+args[0], fields, res)
+	}},
+
+	"store list": {args: 1, columns: storeColumns, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.ListStores(args[0], opts.status, "")
+	}},
+	"store get": {args: 2, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		return c.GetStore(args[0], args[1])
+	}},
+	"store create": {args: 1, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		body := &doordash.NewStore{}
+		if err := readBody(opts, body); err != nil {
+			return nil, err
+		}
+		return c.CreateStore(args[0], body)
+	}},
+	"store update": {args: 2, run: func(c *doordash.Client, args []string, opts *options) (interface{}, error) {
+		body := &doordash.StoreUpdate{}
+		fields, err := readFields(opts, body)
+		if err != nil {
+			return nil, err
+		}
+		if err := body.Validate(); err != nil {
+			return nil, err
+		}
+		res := &doordash.StoreInfo{}
+		return res, patch(c, "UpdateStore", "developer/v1/businesses/"+args[0]+"/stores/"+args[1], fields, res)
+	}},
+}
+
+// patch sends only the fields given by the user. The SDK's update types
+// would send every field and clear the ones left out.
+func patch(c *doordash.Client, operation string, path string, fields map[string]interface{}, res interface{}) error {
+	req, err := c.NewRequest("PATCH", path, fields)
+	if err != nil {
+		return err
+	}
+	return c.Do(req, res, doordash.WithOperation(operation))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Token   string `json:"token" yaml:"token"`
+	BaseURL string `json:"base_url" yaml:"base_url"`
+
+	// Developer Portal access key, used to sign requests instead of Token
+	DeveloperID   string `json:"developer_id" yaml:"developer_id"`
+	KeyID         string `json:"key_id" yaml:"key_id"`
+	SigningSecret string `json:"signing_secret" yaml:"signing_secret"`
+}
+
+// loadConfig reads credentials from the environment, falling back to the
+// config file for anything not set there. A missing default config file is
+// not an error; a missing explicit one is.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := &config{}
+
+	explicit := path != ""
+	if !explicit {
+		if home := getenv("HOME"); home != "" {
+			path = filepath.Join(home, ".config", "doordash", "config.yaml")
+		}
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := decodeFile(path, data, cfg); err != nil {
+				return nil, fmt.Errorf("reading config %s: %v", path, err)
+			}
+		case explicit || !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
+	if token := getenv("DOORDASH_TOKEN"); token != "" {
+		cfg.Token = token
+	}
+	if baseURL := getenv("DOORDASH_BASE_URL"); baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	for env, field := range map[string]*string{
+		"DOORDASH_DEVELOPER_ID":   &cfg.DeveloperID,
+		"DOORDASH_KEY_ID":         &cfg.KeyID,
+		"DOORDASH_SIGNING_SECRET": &cfg.SigningSecret,
+	} {
+		if v := getenv(env); v != "" {
+			*field = v
+		}
+	}
+
+	if cfg.Token == "" && cfg.DeveloperID == "" {
+		return nil, fmt.Errorf("no credentials: set DOORDASH_TOKEN or DOORDASH_DEVELOPER_ID, DOORDASH_KEY_ID and DOORDASH_SIGNING_SECRET, or add them to %s", path)
+	}
+	return cfg, nil
+}
+
+func (cfg *config) client() (*doordash.Client, error) {
+	client := doordash.NewClient(cfg.Token)
+	if cfg.DeveloperID != "" {
+		client = doordash.NewClientWithCredentials(doordash.StaticCredentials(doordash.Credentials{
+			DeveloperID:   cfg.DeveloperID,
+			KeyID:         cfg.KeyID,
+			SigningSecret: cfg.SigningSecret,
+		}))
+	}
+	if cfg.BaseURL != "" {
+		baseURL := cfg.BaseURL
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base_url %q: %v", cfg.BaseURL, err)
+		}
+		client.BaseURL = u
+	}
+	return client, nil
+}
+
+// decodeFile decodes JSON or YAML, picking the format from the file extension
+func decodeFile(path string, data []byte, v interface{}) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, v)
+	default:
+		return json.Unmarshal(data, v)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("token: file-token\nbase_url: https://example.com/api\n"), 0600)
+
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	cfg, err := loadConfig(path, getenv)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if cfg.Token != "file-token" {
+		t.Errorf("expected token from file, got %q", cfg.Token)
+	}
+
+	client, err := cfg.client()
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if got := client.BaseURL.String(); got != "https://example.com/api/" {
+		t.Errorf("expected base URL with trailing slash, got %s", got)
+	}
+
+	env["DOORDASH_TOKEN"] = "env-token"
+	if cfg, _ := loadConfig(path, getenv); cfg.Token != "env-token" {
+		t.Errorf("expected environment to override file, got %q", cfg.Token)
+	}
+
+	if _, err := loadConfig(filepath.Join(dir, "missing.yaml"), getenv); err == nil {
+		t.Error("expected error for missing explicit config file, got nil")
+	}
+
+	env = map[string]string{"HOME": dir}
+	if _, err := loadConfig("", getenv); err == nil {
+		t.Error("expected error without credentials, got nil")
+	}
+}
+
+func TestLoadConfigAccessKey(t *testing.T) {
+	env := map[string]string{
+		"DOORDASH_DEVELOPER_ID":   "dev-1",
+		"DOORDASH_KEY_ID":         "key-1",
+		"DOORDASH_SIGNING_SECRET": "c2VjcmV0",
+	}
+	cfg, err := loadConfig("", func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	client, _ := cfg.client()
+	req, err := client.NewRequest("GET", "/foo", nil)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if got := doordash.SigningKeyID(req); got != "key-1" {
+		t.Errorf("expected request signed with key-1, got %q", got)
+	}
+}
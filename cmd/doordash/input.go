@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+type options struct {
+	config string
+	file   string
+	output string
+	status string
+	set    setFlags
+}
+
+// setFlags collects repeated -set field=value flags
+type setFlags []string
+
+func (s *setFlags) String() string { return strings.Join(*s, ",") }
+
+func (s *setFlags) Set(v string) error {
+	if !strings.Contains(v, "=") {
+		return fmt.Errorf("expected field=value, got %q", v)
+	}
+	*s = append(*s, v)
+	return nil
+}
+
+// parseFlags parses flags that may appear before, between or after positional
+// arguments, returning the positional arguments in order.
+func parseFlags(name string, args []string) (*options, []string, error) {
+	opts := &options{}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.config, "config", "", "config file")
+	fs.StringVar(&opts.file, "f", "", "request body file")
+	fs.StringVar(&opts.output, "o", "table", "output format")
+	fs.StringVar(&opts.status, "status", "", "activation status filter")
+	fs.Var(&opts.set, "set", "request field")
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	switch opts.output {
+	case "table", "json", "yaml":
+	default:
+		return nil, nil, fmt.Errorf("unknown output format %q", opts.output)
+	}
+	return opts, positional, nil
+}
+
+// readBody fills v from the -f file, then applies any -set overrides. Fields
+// are addressed by their JSON names, e.g. -set dropoff_phone_number=+1555.
+func readBody(opts *options, v interface{}) error {
+	_, err := readFields(opts, v)
+	return err
+}
+
+// readFields is readBody that also returns the fields given by the user, so
+// updates can send those alone
+func readFields(opts *options, v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if opts.file != "" {
+		data, err := os.ReadFile(opts.file)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeFile(opts.file, data, &fields); err != nil {
+			return nil, fmt.Errorf("reading %s: %v", opts.file, err)
+		}
+		for name := range fields {
+			if !hasField(v, name) {
+				return nil, fmt.Errorf("reading %s: unknown field %q", opts.file, name)
+			}
+		}
+	}
+
+	for _, kv := range opts.set {
+		parts := strings.SplitN(kv, "=", 2)
+		value, err := fieldValue(v, parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+		fields[parts[0]] = value
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("missing request body: use -f or -set")
+	}
+
+	// Round-trip through JSON so the SDK's json tags apply to YAML input too
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// hasField reports whether the struct v points to has a field with the given
+// JSON name
+func hasField(v interface{}, name string) bool {
+	_, ok := fieldType(v, name)
+	return ok
+}
+
+func fieldType(v interface{}, name string) (reflect.Type, bool) {
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+// fieldValue converts a -set value according to the type of the struct field
+// with the given JSON name: strings are taken verbatim, anything else is
+// parsed as JSON.
+func fieldValue(v interface{}, name string, raw string) (interface{}, error) {
+	t, ok := fieldType(v, name)
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", name)
+	}
+	if t.Kind() == reflect.String {
+		return raw, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		// time.Time and other text-encoded values
+		return raw, nil
+	}
+	return value, nil
+}
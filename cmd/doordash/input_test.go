@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestParseFlags(t *testing.T) {
+	opts, positional, err := parseFlags("store get", []string{"-o", "yaml", "B-1", "-status", "active", "S-1"})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if !reflect.DeepEqual(positional, []string{"B-1", "S-1"}) {
+		t.Errorf("unexpected positional arguments %v", positional)
+	}
+	if opts.output != "yaml" || opts.status != "active" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.yaml")
+	os.WriteFile(path, []byte("external_store_id: S-1\nname: Deli\nphone_number: \"+12065551212\"\n"), 0600)
+
+	opts := &options{file: path, set: setFlags{"name=Deli #2", "phone_number=12065550000"}}
+	got := &doordash.NewStore{}
+	if err := readBody(opts, got); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	want := &doordash.NewStore{ExternalStoreID: "S-1", Name: "Deli #2", PhoneNumber: "12065550000"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected body to be %v, got %v", want, got)
+	}
+
+	os.WriteFile(path, []byte("colour: blue\n"), 0600)
+	if err := readBody(&options{file: path}, &doordash.NewStore{}); err == nil {
+		t.Error("expected error for unknown field in file, got nil")
+	}
+
+	opts = &options{set: setFlags{"colour=blue"}}
+	if err := readBody(opts, &doordash.NewStore{}); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
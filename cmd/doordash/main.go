@@ -0,0 +1,94 @@
+// Command doordash is a command-line client for the DoorDash Drive API.
+//
+// Usage:
+//
+//	doordash <resource> <action> [arguments] [flags]
+//
+// Resources and actions:
+//
+//	delivery create|get|update|cancel
+//	quote    create|accept
+//	business list|get|create|update
+//	store    list|get|create|update
+//
+// Credentials are read from the environment, falling back to the config file
+// given by -config (default $HOME/.config/doordash/config.yaml). Either set
+// DOORDASH_DEVELOPER_ID, DOORDASH_KEY_ID and DOORDASH_SIGNING_SECRET to sign
+// requests with a Developer Portal access key, or DOORDASH_TOKEN to use a
+// ready-made token. DOORDASH_BASE_URL overrides the API endpoint.
+//
+// Request bodies are read from a JSON or YAML file with -f and individual
+// fields can be set or overridden with -set field=value. Results are printed
+// as a table, JSON or YAML (-o).
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: doordash <resource> <action> [arguments] [flags]
+
+  delivery create -f body.json
+  delivery get <external_delivery_id>
+  delivery update <external_delivery_id> -f body.json
+  delivery cancel <external_delivery_id>
+  quote create -f body.json
+  quote accept <external_delivery_id>
+  business list [-status active]
+  business get <external_business_id>
+  business create -f body.json
+  business update <external_business_id> -f body.json
+  store list <external_business_id> [-status active]
+  store get <external_business_id> <external_store_id>
+  store create <external_business_id> -f body.json
+  store update <external_business_id> <external_store_id> -f body.json
+
+flags:
+  -config path   config file with credentials and base_url
+  -f path        JSON or YAML request body
+  -set k=v       set a request field, may be repeated
+  -o format      output format: table, json or yaml (default table)
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, "doordash:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, getenv func(string) string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("missing resource or action\n\n%s", usage)
+	}
+
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", args[0]+" "+args[1], usage)
+	}
+
+	opts, positional, err := parseFlags(args[0]+" "+args[1], args[2:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != cmd.args {
+		return fmt.Errorf("%s %s expects %d argument(s), got %d\n\n%s", args[0], args[1], cmd.args, len(positional), usage)
+	}
+
+	cfg, err := loadConfig(opts.config, getenv)
+	if err != nil {
+		return err
+	}
+	client, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	res, err := cmd.run(client, positional, opts)
+	if err != nil {
+		return err
+	}
+	return writeOutput(stdout, opts.output, res, cmd.columns)
+}
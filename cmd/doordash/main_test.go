@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testEnv(server *httptest.Server) func(string) string {
+	env := map[string]string{
+		"DOORDASH_TOKEN":    "token",
+		"DOORDASH_BASE_URL": server.URL,
+	}
+	return func(key string) string { return env[key] }
+}
+
+func TestRunDeliveryCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Test request parameters
+		if req.Method != "POST" || req.URL.Path != "/drive/v2/deliveries" {
+			t.Errorf("expected POST /drive/v2/deliveries, got %s %s", req.Method, req.URL.Path)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if body["external_delivery_id"] != "D-12345" || body["order_value"] != float64(1999) {
+			t.Errorf("unexpected request body %v", body)
+		}
+		// Send response to be tested
+		rw.Write([]byte(`{"external_delivery_id": "D-12345", "delivery_status": "created", "fee": 975}`))
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	args := []string{"delivery", "create", "-set", "external_delivery_id=D-12345", "-set", "order_value=1999", "-o", "json"}
+	if err := run(args, out, testEnv(server)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	got := map[string]interface{}{}
+	json.Unmarshal(out.Bytes(), &got)
+	if got["delivery_status"] != "created" || got["fee"] != float64(975) {
+		t.Errorf("unexpected output %s", out)
+	}
+}
+
+func TestRunStoreUpdatePartial(t *testing.T) {
+	var request string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		request, body = req.Method+" "+req.URL.Path, nil
+		json.NewDecoder(req.Body).Decode(&body)
+		// Send response to be tested
+		rw.Write([]byte(`{"external_store_id": "S-1", "name": "Deli", "timezone": "America/New_York"}`))
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	args := []string{"store", "update", "B-1", "S-1", "-set", "timezone=America/New_York", "-o", "json"}
+	if err := run(args, out, testEnv(server)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if request != "PATCH /developer/v1/businesses/B-1/stores/S-1" {
+		t.Errorf("expected PATCH of the store, got %s", request)
+	}
+	if want := map[string]interface{}{"timezone": "America/New_York"}; !reflect.DeepEqual(body, want) {
+		t.Errorf("expected only the given field to be sent, got %v", body)
+	}
+
+	args = []string{"delivery", "update", "D-1", "-set", "tip=0"}
+	if err := run(args, out, testEnv(server)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if request != "PATCH /drive/v2/deliveries/D-1" {
+		t.Errorf("expected PATCH of the delivery, got %s", request)
+	}
+	if want := map[string]interface{}{"tip": float64(0)}; !reflect.DeepEqual(body, want) {
+		t.Errorf("expected only the given field to be sent, got %v", body)
+	}
+}
+
+func TestRunStoreList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Test request parameters
+		if got := req.URL.String(); got != "/developer/v1/businesses/B-12345/stores?activation_status=active" {
+			t.Errorf("unexpected request URL %s", got)
+		}
+		// Send response to be tested
+		rw.Write([]byte(`{"result": [{"external_store_id": "S-1", "name": "Deli", "address": "1 Main St", "phone_number": "+12065551212", "status": "active"}]}`))
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	if err := run([]string{"store", "list", "B-12345", "-status", "active"}, out, testEnv(server)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "EXTERNAL_STORE_ID") || !strings.HasPrefix(lines[1], "S-1") {
+		t.Errorf("unexpected table output %q", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"code": "not_found", "message": "Delivery not found"}`))
+	}))
+	defer server.Close()
+
+	tests := [][]string{
+		{"delivery"},
+		{"delivery", "teleport"},
+		{"delivery", "get"},
+		{"delivery", "get", "D-12345", "-o", "xml"},
+		{"delivery", "get", "D-12345"},
+		{"business", "create"},
+	}
+	for _, args := range tests {
+		if err := run(args, &bytes.Buffer{}, testEnv(server)); err == nil {
+			t.Errorf("expected error for %v, got nil", args)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+func writeOutput(w io.Writer, format string, v interface{}, columns []string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		// Round-trip through JSON so YAML keys match the API's field names
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(generic)
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		var err error
+		if columns != nil {
+			err = writeRows(tw, v, columns)
+		} else {
+			err = writeFields(tw, v)
+		}
+		if err != nil {
+			return err
+		}
+		return tw.Flush()
+	}
+}
+
+// writeFields prints a single object as one "field value" line per field
+func writeFields(w io.Writer, v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		value, err := cell(rv.Field(i).Interface())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, value)
+	}
+	return nil
+}
+
+// writeRows prints the "result" array of a list response as a table
+func writeRows(w io.Writer, v interface{}, columns []string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var list struct {
+		Result []map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range list.Result {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			if values[i], err = cell(row[col]); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return nil
+}
+
+// cell formats a value for a table: strings verbatim, everything else as JSON
+func cell(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
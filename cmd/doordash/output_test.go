@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestWriteOutput(t *testing.T) {
+	business := &doordash.BusinessInfo{Name: "Deli", ExternalBusinessID: "B-1", ActivationStatus: "active"}
+
+	out := &bytes.Buffer{}
+	writeOutput(out, "yaml", business, nil)
+	if got := out.String(); !bytes.Contains([]byte(got), []byte("external_business_id: B-1\n")) {
+		t.Errorf("expected YAML keys to use API field names, got %q", got)
+	}
+
+	out.Reset()
+	writeOutput(out, "table", business, nil)
+	if got := out.String(); !bytes.Contains([]byte(got), []byte("activation_status     active\n")) {
+		t.Errorf("unexpected table output %q", got)
+	}
+
+	out.Reset()
+	list := &doordash.BusinessInfoList{Result: []doordash.BusinessInfo{*business}}
+	writeOutput(out, "table", list, businessColumns)
+	want := "EXTERNAL_BUSINESS_ID  NAME  ACTIVATION_STATUS  IS_TEST\nB-1                   Deli  active             false\n"
+	if got := out.String(); got != want {
+		t.Errorf("expected table %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,118 @@
+// Bulk delivery creation
+package doordash
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	BulkStatusCreated = "created"
+	BulkStatusFailed  = "failed"
+)
+
+// Options for creating deliveries in bulk
+type BulkOptions struct {
+	// Maximum number of deliveries created concurrently. Defaults to 1.
+	Concurrency int
+	// Maximum number of requests per second across all workers. Zero means
+	// no rate limit.
+	RateLimit float64
+	// Results of a previous, partially failed run. Deliveries that were
+	// already created are not sent again and their results are carried over,
+	// so the new results describe the whole batch.
+	Previous []BulkResult
+}
+
+// Outcome of creating a single delivery in a bulk run
+type BulkResult struct {
+	// Zero-based position of the delivery in the input
+	Row                int    `json:"row"`
+	ExternalDeliveryID string `json:"external_delivery_id"`
+	Status             string `json:"status"`
+	Fee                int    `json:"fee,omitempty"`
+	TrackingURL        string `json:"tracking_url,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// CreateDeliveries creates each delivery, returning one result per input row
+// in input order. Failures are recorded in the results rather than stopping
+// the run; if ctx is cancelled, deliveries not yet sent are marked as failed
+// so they are retried when the results are passed back as BulkOptions.Previous.
+func (c *Client) CreateDeliveries(ctx context.Context, deliveries []*NewDelivery, opts BulkOptions) []BulkResult {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	created := map[string]BulkResult{}
+	for _, r := range opts.Previous {
+		if r.Status == BulkStatusCreated {
+			created[r.ExternalDeliveryID] = r
+		}
+	}
+
+	var limit <-chan time.Time
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+
+	results := make([]BulkResult, len(deliveries))
+	rows := make(chan int)
+	done := make(chan struct{})
+	for w := 0; w < opts.Concurrency; w++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := range rows {
+				results[i] = c.createBulkDelivery(ctx, i, deliveries[i], limit)
+			}
+		}()
+	}
+
+	for i, d := range deliveries {
+		if prev, ok := created[d.ExternalDeliveryID]; ok {
+			prev.Row = i
+			results[i] = prev
+			continue
+		}
+		rows <- i
+	}
+	close(rows)
+	for w := 0; w < opts.Concurrency; w++ {
+		<-done
+	}
+
+	return results
+}
+
+func (c *Client) createBulkDelivery(ctx context.Context, row int, d *NewDelivery, limit <-chan time.Time) BulkResult {
+	result := BulkResult{Row: row, ExternalDeliveryID: d.ExternalDeliveryID, Status: BulkStatusFailed}
+
+	if limit != nil {
+		select {
+		case <-limit:
+		case <-ctx.Done():
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if err := c.checkStore(ctx, d.PickupExternalBusinessID, d.PickupExternalStoreID); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	res := &DeliveryInfo{}
+	if err := c.makeRequestContext(ctx, "CreateDelivery", "POST", "drive/v2/deliveries", nil, d, res); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = BulkStatusCreated
+	result.Fee = res.Fee
+	result.TrackingURL = res.TrackingURL
+	return result
+}
@@ -0,0 +1,215 @@
+// CSV and JSONL readers and writers for bulk delivery creation
+package doordash
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var bulkResultColumns = []string{"row", "external_delivery_id", "status", "fee", "tracking_url", "error"}
+
+// ReadDeliveriesCSV reads deliveries from CSV. The header row names the
+// NewDelivery fields by their JSON names; nested fields use a dot, e.g.
+// "pickup_window.start_time". Times are RFC3339 and empty cells are skipped.
+func ReadDeliveriesCSV(r io.Reader) ([]*NewDelivery, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %v", err)
+	}
+	for _, col := range header {
+		if _, err := fieldByPath(reflect.ValueOf(&NewDelivery{}).Elem(), col); err != nil {
+			return nil, err
+		}
+	}
+
+	var deliveries []*NewDelivery
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return deliveries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		d := &NewDelivery{}
+		for i, raw := range record {
+			if raw == "" {
+				continue
+			}
+			field, _ := fieldByPath(reflect.ValueOf(d).Elem(), header[i])
+			if err := setField(field, raw); err != nil {
+				return nil, fmt.Errorf("line %d, column %s: %v", line, header[i], err)
+			}
+		}
+		deliveries = append(deliveries, d)
+	}
+}
+
+// ReadDeliveriesJSONL reads one JSON-encoded NewDelivery per line, skipping
+// blank lines
+func ReadDeliveriesJSONL(r io.Reader) ([]*NewDelivery, error) {
+	var deliveries []*NewDelivery
+	err := readJSONL(r, func(line int, data []byte) error {
+		d := &NewDelivery{}
+		if err := json.Unmarshal(data, d); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		deliveries = append(deliveries, d)
+		return nil
+	})
+	return deliveries, err
+}
+
+// WriteBulkResultsCSV writes results as CSV with a header row
+func WriteBulkResultsCSV(w io.Writer, results []BulkResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write(bulkResultColumns)
+	for _, r := range results {
+		cw.Write([]string{
+			strconv.Itoa(r.Row),
+			r.ExternalDeliveryID,
+			r.Status,
+			strconv.Itoa(r.Fee),
+			r.TrackingURL,
+			r.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadBulkResultsCSV reads results written by WriteBulkResultsCSV, e.g. to
+// resume a partially failed run
+func ReadBulkResultsCSV(r io.Reader) ([]BulkResult, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !reflect.DeepEqual(records[0], bulkResultColumns) {
+		return nil, fmt.Errorf("missing bulk result CSV header")
+	}
+
+	var results []BulkResult
+	for i, rec := range records[1:] {
+		row, err := strconv.Atoi(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid row %q", i+2, rec[0])
+		}
+		fee, err := strconv.Atoi(rec[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid fee %q", i+2, rec[3])
+		}
+		results = append(results, BulkResult{
+			Row:                row,
+			ExternalDeliveryID: rec[1],
+			Status:             rec[2],
+			Fee:                fee,
+			TrackingURL:        rec[4],
+			Error:              rec[5],
+		})
+	}
+	return results, nil
+}
+
+// WriteBulkResultsJSONL writes one JSON-encoded result per line
+func WriteBulkResultsJSONL(w io.Writer, results []BulkResult) error {
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBulkResultsJSONL reads results written by WriteBulkResultsJSONL
+func ReadBulkResultsJSONL(r io.Reader) ([]BulkResult, error) {
+	var results []BulkResult
+	err := readJSONL(r, func(line int, data []byte) error {
+		var res BulkResult
+		if err := json.Unmarshal(data, &res); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		results = append(results, res)
+		return nil
+	})
+	return results, err
+}
+
+func readJSONL(r io.Reader, fn func(line int, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		data := scanner.Bytes()
+		if len(strings.TrimSpace(string(data))) == 0 {
+			continue
+		}
+		if err := fn(line, data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// fieldByPath finds the struct field with the given dotted JSON name path
+func fieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() != reflect.Struct || v.Type() == reflect.TypeOf(time.Time{}) {
+			return reflect.Value{}, fmt.Errorf("unknown column %q", path)
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0] == name {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, fmt.Errorf("unknown column %q", path)
+		}
+	}
+	return v, nil
+}
+
+func setField(field reflect.Value, raw string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(int64(n))
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(b)
+	case time.Time:
+		// Dates without a time are midnight UTC
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", raw); err != nil {
+				return fmt.Errorf("invalid RFC3339 time or date %q", raw)
+			}
+		}
+		field.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
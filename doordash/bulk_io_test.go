@@ -0,0 +1,88 @@
+package doordash
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadDeliveriesCSV(t *testing.T) {
+	input := `external_delivery_id,dropoff_address,order_value,contactless_dropoff,pickup_window.start_time
+D-1,"901 Market Street, San Francisco, CA 94103",1999,true,2018-08-22T17:20:28Z
+D-2,1 Main Street,,,
+`
+	got, err := ReadDeliveriesCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	timeStamp, _ := time.Parse(time.RFC3339, "2018-08-22T17:20:28Z")
+	want := []*NewDelivery{
+		{
+			ExternalDeliveryID: "D-1",
+			DropoffAddress:     "901 Market Street, San Francisco, CA 94103",
+			OrderValue:         1999,
+			ContactlessDropoff: true,
+			PickupWindow:       TimeWindow{StartTime: timeStamp},
+		},
+		{ExternalDeliveryID: "D-2", DropoffAddress: "1 Main Street"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected deliveries to be %v, got %v", want, got)
+	}
+
+	if _, err := ReadDeliveriesCSV(strings.NewReader("external_delivery_id,colour\nD-1,blue\n")); err == nil {
+		t.Error("expected error for unknown column, got nil")
+	}
+	if _, err := ReadDeliveriesCSV(strings.NewReader("order_value\nlots\n")); err == nil {
+		t.Error("expected error for invalid integer, got nil")
+	}
+}
+
+func TestReadDeliveriesJSONL(t *testing.T) {
+	input := `{"external_delivery_id": "D-1", "order_value": 1999}
+
+{"external_delivery_id": "D-2"}
+`
+	got, err := ReadDeliveriesJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	want := []*NewDelivery{
+		{ExternalDeliveryID: "D-1", OrderValue: 1999},
+		{ExternalDeliveryID: "D-2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected deliveries to be %v, got %v", want, got)
+	}
+}
+
+func TestBulkResultsRoundTrip(t *testing.T) {
+	results := []BulkResult{
+		{Row: 0, ExternalDeliveryID: "D-1", Status: BulkStatusCreated, Fee: 975, TrackingURL: "https://doordash.com/tracking?id=D-1"},
+		{Row: 1, ExternalDeliveryID: "D-2", Status: BulkStatusFailed, Error: "doordash: 400 Bad Request, \"quoted\""},
+	}
+
+	buf := &bytes.Buffer{}
+	WriteBulkResultsCSV(buf, results)
+	got, err := ReadBulkResultsCSV(buf)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if !reflect.DeepEqual(got, results) {
+		t.Errorf("expected CSV results to be %v, got %v", results, got)
+	}
+
+	buf.Reset()
+	WriteBulkResultsJSONL(buf, results)
+	got, err = ReadBulkResultsJSONL(buf)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if !reflect.DeepEqual(got, results) {
+		t.Errorf("expected JSONL results to be %v, got %v", results, got)
+	}
+}
@@ -0,0 +1,75 @@
+package doordash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestCreateDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Test request parameters
+		reqURL := req.URL.String()
+		if reqURL != "/drive/v2/deliveries" {
+			t.Errorf("expected request URL to be /drive/v2/deliveries, got %s", reqURL)
+		}
+		d := &NewDelivery{}
+		json.NewDecoder(req.Body).Decode(d)
+		switch d.ExternalDeliveryID {
+		case "D-2":
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code": "validation_error", "message": "Invalid dropoff address"}`))
+		case "D-3":
+			t.Error("expected previously created delivery not to be sent again")
+		default:
+			// Send response to be tested
+			rw.Write([]byte(`{"external_delivery_id": "` + d.ExternalDeliveryID + `", "fee": 975, "tracking_url": "https://doordash.com/tracking?id=` + d.ExternalDeliveryID + `"}`))
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	deliveries := []*NewDelivery{
+		{ExternalDeliveryID: "D-1"},
+		{ExternalDeliveryID: "D-2"},
+		{ExternalDeliveryID: "D-3"},
+	}
+	previous := []BulkResult{
+		{Row: 0, ExternalDeliveryID: "D-3", Status: BulkStatusCreated, Fee: 800, TrackingURL: "https://doordash.com/tracking?id=D-3"},
+	}
+
+	url, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
+	got := client.CreateDeliveries(context.Background(), deliveries, BulkOptions{Concurrency: 2, RateLimit: 100, Previous: previous})
+
+	want := []BulkResult{
+		{Row: 0, ExternalDeliveryID: "D-1", Status: BulkStatusCreated, Fee: 975, TrackingURL: "https://doordash.com/tracking?id=D-1"},
+		{Row: 1, ExternalDeliveryID: "D-2", Status: BulkStatusFailed, Error: "doordash: 400 Bad Request: validation_error: Invalid dropoff address"},
+		{Row: 2, ExternalDeliveryID: "D-3", Status: BulkStatusCreated, Fee: 800, TrackingURL: "https://doordash.com/tracking?id=D-3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected results to be %v, got %v", want, got)
+	}
+}
+
+func TestCreateDeliveriesCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Error("expected no requests after cancellation")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	url, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
+	got := client.CreateDeliveries(ctx, []*NewDelivery{{ExternalDeliveryID: "D-1"}}, BulkOptions{})
+
+	if len(got) != 1 || got[0].Status != BulkStatusFailed || got[0].Error != context.Canceled.Error() {
+		t.Errorf("expected cancelled delivery to be marked failed, got %v", got)
+	}
+}
@@ -2,6 +2,8 @@
 package doordash
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/url"
 	"time"
 )
@@ -35,6 +37,23 @@ type BusinessInfo struct {
 		ClientEmail       string `json:"client_email"`
 		ClientPhoneNumber string `json:"client_phone_number"`
 	} `json:"external_metadata"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b *BusinessInfo) UnmarshalJSON(data []byte) error {
+	type plain BusinessInfo
+	return unmarshalWithExtra(data, (*plain)(b), &b.Extra)
+}
+
+func (b BusinessInfo) MarshalJSON() ([]byte, error) {
+	type plain BusinessInfo
+	return marshalWithExtra(plain(b), b.Extra)
+}
+
+func (b *BusinessInfo) unknownFields() []string {
+	return extraKeys("", b.Extra)
 }
 
 // Object containing response information for mulitple businesses
@@ -42,37 +61,74 @@ type BusinessInfoList struct {
 	Result            []BusinessInfo `json:"result"`
 	ContinuationToken string         `json:"continuation_token"`
 	ResultCount       int            `json:"result_count"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (l *BusinessInfoList) UnmarshalJSON(data []byte) error {
+	type plain BusinessInfoList
+	return unmarshalWithExtra(data, (*plain)(l), &l.Extra)
+}
+
+func (l BusinessInfoList) MarshalJSON() ([]byte, error) {
+	type plain BusinessInfoList
+	return marshalWithExtra(plain(l), l.Extra)
 }
 
+func (l *BusinessInfoList) unknownFields() []string {
+	fields := extraKeys("", l.Extra)
+	for i := range l.Result {
+		fields = append(fields, extraKeys(fmt.Sprintf("result[%d].", i), l.Result[i].Extra)...)
+	}
+	return fields
+}
+
+// CreateBusiness creates a business. A new business cannot be checked
+// against the client's environment beforehand, so a mismatch is returned
+// together with the created business as *EnvironmentMismatchError with
+// Written set.
+//
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/CreateBusiness
-func (c *Client) CreateBusiness(b *NewBusiness) (*BusinessInfo, error) {
+func (c *Client) CreateBusiness(b *NewBusiness, opts ...CallOption) (*BusinessInfo, error) {
 	res := &BusinessInfo{}
-	if err := c.makeRequest("POST", "/developer/v1/businesses", nil, b, res); err != nil {
+	if err := c.makeRequest("CreateBusiness", "POST", "/developer/v1/businesses", nil, b, res, opts...); err != nil {
 		return nil, err
 	}
+	if err := c.checkWritten("business", res.ExternalBusinessID, res.IsTest); err != nil {
+		return res, err
+	}
 
 	return res, nil
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/ListBusiness
-func (c *Client) ListBusinesses(activationStatus string, paginationToken string) (*BusinessInfoList, error) {
+func (c *Client) ListBusinesses(activationStatus string, paginationToken string, opts ...CallOption) (*BusinessInfoList, error) {
 	params := url.Values{
 		"activation_status": []string{activationStatus},
 		"pagination_token":  []string{paginationToken},
 	}
 
 	res := &BusinessInfoList{}
-	if err := c.makeRequest("GET", "/developer/v1/businesses", params, nil, res); err != nil {
+	if err := c.makeRequest("ListBusinesses", "GET", "/developer/v1/businesses", params, nil, res, opts...); err != nil {
 		return nil, err
 	}
+	for _, b := range res.Result {
+		if err := c.checkEnvironment("business", b.ExternalBusinessID, b.IsTest); err != nil {
+			return nil, err
+		}
+	}
 
 	return res, nil
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/GetBusiness
-func (c *Client) GetBusiness(externalBusinessID string) (*BusinessInfo, error) {
+func (c *Client) GetBusiness(externalBusinessID string, opts ...CallOption) (*BusinessInfo, error) {
 	res := &BusinessInfo{}
-	if err := c.makeRequest("GET", ("/developer/v1/businesses/" + externalBusinessID), nil, nil, res); err != nil {
+	if err := c.makeRequest("GetBusiness", "GET", ("/developer/v1/businesses/" + externalBusinessID), nil, nil, res, opts...); err != nil {
+		return nil, err
+	}
+	if err := c.checkEnvironment("business", res.ExternalBusinessID, res.IsTest); err != nil {
 		return nil, err
 	}
 
@@ -80,11 +136,18 @@ func (c *Client) GetBusiness(externalBusinessID string) (*BusinessInfo, error) {
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/UpdateBusiness
-func (c *Client) UpdateBusiness(externalBusinessID string, b *BusinessUpdate) (*BusinessInfo, error) {
+func (c *Client) UpdateBusiness(externalBusinessID string, b *BusinessUpdate, opts ...CallOption) (*BusinessInfo, error) {
+	if err := c.checkBusiness(callContext(opts), externalBusinessID); err != nil {
+		return nil, err
+	}
+
 	res := &BusinessInfo{}
-	if err := c.makeRequest("PATCH", ("/developer/v1/businesses/" + externalBusinessID), nil, b, res); err != nil {
+	if err := c.makeRequest("UpdateBusiness", "PATCH", ("/developer/v1/businesses/" + externalBusinessID), nil, b, res, opts...); err != nil {
 		return nil, err
 	}
+	if err := c.checkWritten("business", res.ExternalBusinessID, res.IsTest); err != nil {
+		return res, err
+	}
 
 	return res, nil
 }
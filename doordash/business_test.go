@@ -66,7 +66,7 @@ func TestCreateBusiness(t *testing.T) {
 	}
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.CreateBusiness(payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -83,8 +83,8 @@ func TestListBusinesses(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		// Test request parameters
 		reqURL := req.URL.String()
-		if reqURL != "/developer/v1/businesses" {
-			t.Errorf("expected request URL to be /developer/v1/businesses, got %s", reqURL)
+		if reqURL != "/developer/v1/businesses?activation_status=active&pagination_token=token" {
+			t.Errorf("expected request URL to be /developer/v1/businesses?activation_status=active&pagination_token=token, got %s", reqURL)
 		}
 		// Send response to be tested
 		rw.Write(businessListResponse)
@@ -93,7 +93,7 @@ func TestListBusinesses(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.ListBusinesses("active", "token")
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -121,7 +121,7 @@ func TestGetBusiness(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.GetBusiness(testID)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -155,7 +155,7 @@ func TestUpdateBusiness(t *testing.T) {
 	}
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.UpdateBusiness(testID, payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -0,0 +1,31 @@
+// Package classic covers the Drive Classic (v1) API for integrations not yet
+// migrated to Drive v2. It shares the transport, authentication and
+// middleware of a doordash.Client; the mapping helpers in this package
+// convert Classic models to their v2 equivalents.
+//
+// API Doc: https://developer.doordash.com/en-US/docs/drive_classic/overview
+package classic
+
+import (
+	"context"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Drive Classic client
+type Client struct {
+	client *doordash.Client
+}
+
+// New returns a Drive Classic client sending its requests through c
+func New(c *doordash.Client) *Client {
+	return &Client{client: c}
+}
+
+func (c *Client) makeRequest(ctx context.Context, operation string, method string, endpoint string, body interface{}, res interface{}, opts []doordash.CallOption) error {
+	req, err := c.client.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	return c.client.Do(req, res, append([]doordash.CallOption{doordash.WithOperation("classic." + operation)}, opts...)...)
+}
@@ -0,0 +1,100 @@
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#tag/Deliveries
+package classic
+
+import (
+	"context"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Recipient of a Classic delivery
+type Customer struct {
+	PhoneNumber             string `json:"phone_number"`
+	BusinessName            string `json:"business_name,omitempty"`
+	FirstName               string `json:"first_name,omitempty"`
+	LastName                string `json:"last_name,omitempty"`
+	Email                   string `json:"email,omitempty"`
+	ShouldSendNotifications bool   `json:"should_send_notifications"`
+}
+
+// Object for creating a new Classic delivery
+type DeliveryRequest struct {
+	ExternalDeliveryID   string     `json:"external_delivery_id,omitempty"`
+	PickupAddress        Address    `json:"pickup_address"`
+	PickupPhoneNumber    string     `json:"pickup_phone_number"`
+	PickupBusinessName   string     `json:"pickup_business_name,omitempty"`
+	PickupInstructions   string     `json:"pickup_instructions,omitempty"`
+	DropoffAddress       Address    `json:"dropoff_address"`
+	DropoffInstructions  string     `json:"dropoff_instructions,omitempty"`
+	Customer             Customer   `json:"customer"`
+	OrderValue           int        `json:"order_value"`
+	Tip                  int        `json:"tip,omitempty"`
+	PickupTime           *time.Time `json:"pickup_time,omitempty"`
+	DeliveryTime         *time.Time `json:"delivery_time,omitempty"`
+	ExternalBusinessName string     `json:"external_business_name,omitempty"`
+	ExternalStoreID      string     `json:"external_store_id,omitempty"`
+	ContainsAlcohol      bool       `json:"contains_alcohol"`
+}
+
+// Object for updating a Classic delivery. Only set fields are sent.
+type DeliveryUpdate struct {
+	PickupTime          *time.Time `json:"pickup_time,omitempty"`
+	DeliveryTime        *time.Time `json:"delivery_time,omitempty"`
+	PickupInstructions  string     `json:"pickup_instructions,omitempty"`
+	DropoffInstructions string     `json:"dropoff_instructions,omitempty"`
+	OrderValue          int        `json:"order_value,omitempty"`
+	Tip                 int        `json:"tip,omitempty"`
+}
+
+// Object containing response information for Classic deliveries
+type Delivery struct {
+	ID                   int       `json:"id"`
+	ExternalDeliveryID   string    `json:"external_delivery_id"`
+	Status               string    `json:"status"`
+	Fee                  int       `json:"fee"`
+	Currency             string    `json:"currency"`
+	Tip                  int       `json:"tip"`
+	OrderValue           int       `json:"order_value"`
+	PickupAddress        Address   `json:"pickup_address"`
+	DropoffAddress       Address   `json:"dropoff_address"`
+	Customer             Customer  `json:"customer"`
+	PickupInstructions   string    `json:"pickup_instructions"`
+	DropoffInstructions  string    `json:"dropoff_instructions"`
+	TrackingURL          string    `json:"delivery_tracking_url"`
+	PickupTimeEstimated  time.Time `json:"estimated_pickup_time"`
+	DropoffTimeEstimated time.Time `json:"estimated_delivery_time"`
+	PickupTimeActual     time.Time `json:"actual_pickup_time"`
+	DropoffTimeActual    time.Time `json:"actual_delivery_time"`
+	CancellationReason   string    `json:"cancellation_reason"`
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#operation/CreateDelivery
+func (c *Client) CreateDelivery(ctx context.Context, d *DeliveryRequest, opts ...doordash.CallOption) (*Delivery, error) {
+	return c.makeDeliveryRequest(ctx, "CreateDelivery", "POST", "drive/v1/deliveries", d, opts)
+}
+
+// GetDelivery takes the Classic delivery ID or the external delivery ID
+//
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#operation/GetDelivery
+func (c *Client) GetDelivery(ctx context.Context, deliveryID string, opts ...doordash.CallOption) (*Delivery, error) {
+	return c.makeDeliveryRequest(ctx, "GetDelivery", "GET", ("drive/v1/deliveries/" + deliveryID), nil, opts)
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#operation/UpdateDelivery
+func (c *Client) UpdateDelivery(ctx context.Context, deliveryID string, d *DeliveryUpdate, opts ...doordash.CallOption) (*Delivery, error) {
+	return c.makeDeliveryRequest(ctx, "UpdateDelivery", "PATCH", ("drive/v1/deliveries/" + deliveryID), d, opts)
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#operation/CancelDelivery
+func (c *Client) CancelDelivery(ctx context.Context, deliveryID string, opts ...doordash.CallOption) (*Delivery, error) {
+	return c.makeDeliveryRequest(ctx, "CancelDelivery", "PUT", ("drive/v1/deliveries/" + deliveryID + "/cancel"), nil, opts)
+}
+
+func (c *Client) makeDeliveryRequest(ctx context.Context, operation string, method string, endpoint string, body interface{}, opts []doordash.CallOption) (*Delivery, error) {
+	res := &Delivery{}
+	if err := c.makeRequest(ctx, operation, method, endpoint, body, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package classic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+var deliveryResponse = []byte(`{
+	"id": 98765,
+	"external_delivery_id": "D-12345",
+	"status": "dasher_confirmed",
+	"fee": 975,
+	"currency": "USD",
+	"order_value": 1999,
+	"pickup_address": {"street": "901 Market Street", "unit": "6th Floor", "city": "San Francisco", "state": "CA", "zip_code": "94103"},
+	"dropoff_address": {"street": "1 Dr Carlton B Goodlett Pl", "city": "San Francisco", "state": "CA", "zip_code": "94102"},
+	"customer": {"phone_number": "+16505555555", "first_name": "John", "last_name": "Doe", "should_send_notifications": true},
+	"delivery_tracking_url": "https://doordash.com/tracking?id=",
+	"estimated_delivery_time": "2018-08-22T17:20:28Z"
+}`)
+
+func TestDeliveryEndpoints(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		// Send response to be tested
+		rw.Write(deliveryResponse)
+	})
+
+	d, err := c.CreateDelivery(context.Background(), &DeliveryRequest{ExternalDeliveryID: "D-12345", OrderValue: 1999})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if d.ID != 98765 || d.Customer.FirstName != "John" || d.PickupAddress.Unit != "6th Floor" {
+		t.Errorf("unexpected delivery %+v", d)
+	}
+	c.GetDelivery(context.Background(), "D-12345")
+	c.UpdateDelivery(context.Background(), "D-12345", &DeliveryUpdate{Tip: 500})
+	c.CancelDelivery(context.Background(), "D-12345")
+
+	want := []string{
+		"POST /drive/v1/deliveries",
+		"GET /drive/v1/deliveries/D-12345",
+		"PATCH /drive/v1/deliveries/D-12345",
+		"PUT /drive/v1/deliveries/D-12345/cancel",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected requests %v, got %v", want, requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("expected request %s, got %s", want[i], requests[i])
+		}
+	}
+}
+
+func TestDeliveryCancelledContext(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Write(deliveryResponse)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetDelivery(ctx, "D-12345"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected the cancelled call not to be sent, got %d requests", requests)
+	}
+}
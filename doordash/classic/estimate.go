@@ -0,0 +1,66 @@
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic
+package classic
+
+import (
+	"context"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Structured address used by Drive Classic, where v2 takes a single string
+type Address struct {
+	Street  string `json:"street"`
+	Unit    string `json:"unit,omitempty"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	ZipCode string `json:"zip_code"`
+}
+
+// Object for requesting a fee and time estimate or validating a delivery
+type EstimateRequest struct {
+	PickupAddress        Address    `json:"pickup_address"`
+	DropoffAddress       Address    `json:"dropoff_address"`
+	OrderValue           int        `json:"order_value"`
+	PickupTime           *time.Time `json:"pickup_time,omitempty"`
+	DeliveryTime         *time.Time `json:"delivery_time,omitempty"`
+	ExternalBusinessName string     `json:"external_business_name,omitempty"`
+	ExternalStoreID      string     `json:"external_store_id,omitempty"`
+}
+
+// Object containing response information for estimates
+type Estimate struct {
+	ID           int       `json:"id"`
+	Fee          int       `json:"fee"`
+	Currency     string    `json:"currency"`
+	PickupTime   time.Time `json:"pickup_time"`
+	DeliveryTime time.Time `json:"delivery_time"`
+}
+
+// Object containing response information for validations. Each error is a
+// field name followed by its messages.
+type Validation struct {
+	Valid  bool       `json:"valid"`
+	Errors [][]string `json:"errors"`
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#tag/Estimates
+func (c *Client) CreateEstimate(ctx context.Context, e *EstimateRequest, opts ...doordash.CallOption) (*Estimate, error) {
+	res := &Estimate{}
+	if err := c.makeRequest(ctx, "CreateEstimate", "POST", "drive/v1/estimates", e, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ValidateDelivery checks whether a delivery could be created without
+// creating it
+//
+// API Spec: https://developer.doordash.com/en-US/api/drive_classic#tag/Validations
+func (c *Client) ValidateDelivery(ctx context.Context, e *EstimateRequest, opts ...doordash.CallOption) (*Validation, error) {
+	res := &Validation{}
+	if err := c.makeRequest(ctx, "ValidateDelivery", "POST", "drive/v1/validations", e, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
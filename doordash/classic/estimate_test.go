@@ -0,0 +1,70 @@
+package classic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// newTestClient returns a Classic client sending its requests to handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	// Close the server when test finishes
+	t.Cleanup(server.Close)
+
+	url, _ := url.Parse(server.URL + "/")
+	c := doordash.NewClient("token", doordash.WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	return New(c)
+}
+
+func TestCreateEstimate(t *testing.T) {
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		// Test request parameters
+		if req.Method != "POST" || req.URL.Path != "/drive/v1/estimates" {
+			t.Errorf("expected POST /drive/v1/estimates, got %s %s", req.Method, req.URL.Path)
+		}
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if _, ok := body["pickup_time"]; ok {
+			t.Errorf("expected unset pickup_time to be omitted, got %v", body)
+		}
+		// Send response to be tested
+		rw.Write([]byte(`{"id": 1234, "fee": 975, "currency": "USD", "delivery_time": "2018-08-22T17:20:28Z"}`))
+	})
+
+	estimate, err := c.CreateEstimate(context.Background(), &EstimateRequest{
+		PickupAddress:  Address{Street: "901 Market Street", City: "San Francisco", State: "CA", ZipCode: "94103"},
+		DropoffAddress: Address{Street: "1 Dr Carlton B Goodlett Pl", City: "San Francisco", State: "CA", ZipCode: "94102"},
+		OrderValue:     1999,
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if estimate.ID != 1234 || estimate.Fee != 975 || estimate.DeliveryTime.IsZero() {
+		t.Errorf("unexpected estimate %+v", estimate)
+	}
+}
+
+func TestValidateDelivery(t *testing.T) {
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/drive/v1/validations" {
+			t.Errorf("expected request URL to be /drive/v1/validations, got %s", req.URL.Path)
+		}
+		// Send response to be tested
+		rw.Write([]byte(`{"valid": false, "errors": [["dropoff_address", "Address is outside the delivery area"]]}`))
+	})
+
+	v, err := c.ValidateDelivery(context.Background(), &EstimateRequest{OrderValue: 1999})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if v.Valid || len(v.Errors) != 1 || v.Errors[0][0] != "dropoff_address" {
+		t.Errorf("unexpected validation %+v", v)
+	}
+}
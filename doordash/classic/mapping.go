@@ -0,0 +1,96 @@
+// Conversions from Drive Classic to Drive v2 models
+package classic
+
+import (
+	"strings"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Classic statuses that differ from their v2 names. Other statuses are the
+// same in both versions.
+var v2Statuses = map[string]string{
+	"scheduled":        "created",
+	"assigning_dasher": "created",
+	"dasher_confirmed": "confirmed",
+}
+
+// String formats the address as a single line, the form v2 expects
+func (a Address) String() string {
+	street := a.Street
+	if a.Unit != "" {
+		street += " " + a.Unit
+	}
+	parts := []string{}
+	for _, p := range []string{street, a.City, strings.TrimSpace(a.State + " " + a.ZipCode)} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ToNewDelivery converts a Classic delivery request into a v2 one. v2 needs
+// an external delivery ID, so one must be set on the Classic request. The
+// Classic external_store_id becomes the v2 pickup store, and the store's
+// business has to be set on the result if it is to be used for pickup.
+// Customer email and the alcohol flag have no v2 equivalent and are dropped.
+func ToNewDelivery(d *DeliveryRequest) *doordash.NewDelivery {
+	nd := &doordash.NewDelivery{
+		ExternalDeliveryID:              d.ExternalDeliveryID,
+		PickupAddress:                   d.PickupAddress.String(),
+		PickupBusinessName:              d.PickupBusinessName,
+		PickupPhoneNumber:               d.PickupPhoneNumber,
+		PickupInstructions:              d.PickupInstructions,
+		PickupExternalStoreID:           d.ExternalStoreID,
+		DropoffAddress:                  d.DropoffAddress.String(),
+		DropoffBusinessName:             d.Customer.BusinessName,
+		DropoffPhoneNumber:              d.Customer.PhoneNumber,
+		DropoffInstructions:             d.DropoffInstructions,
+		DropoffContactGivenName:         d.Customer.FirstName,
+		DropoffContactFamilyName:        d.Customer.LastName,
+		DropoffContactSendNotifications: d.Customer.ShouldSendNotifications,
+		OrderValue:                      d.OrderValue,
+		Tip:                             d.Tip,
+	}
+	if d.PickupTime != nil {
+		nd.PickupTime = *d.PickupTime
+	}
+	if d.DeliveryTime != nil {
+		nd.DropoffTime = *d.DeliveryTime
+	}
+	return nd
+}
+
+// ToDeliveryInfo converts a Classic delivery into the v2 response model, so
+// code written against v2 can consume Classic deliveries during migration.
+// The numeric Classic ID has no v2 equivalent and is dropped.
+func ToDeliveryInfo(d *Delivery) *doordash.DeliveryInfo {
+	status, ok := v2Statuses[d.Status]
+	if !ok {
+		status = d.Status
+	}
+	return &doordash.DeliveryInfo{
+		ExternalDeliveryID:              d.ExternalDeliveryID,
+		PickupAddress:                   d.PickupAddress.String(),
+		PickupInstructions:              d.PickupInstructions,
+		DropoffAddress:                  d.DropoffAddress.String(),
+		DropoffBusinessName:             d.Customer.BusinessName,
+		DropoffPhoneNumber:              d.Customer.PhoneNumber,
+		DropoffInstructions:             d.DropoffInstructions,
+		DropoffContactGivenName:         d.Customer.FirstName,
+		DropoffContactFamilyName:        d.Customer.LastName,
+		DropoffContactSendNotifications: d.Customer.ShouldSendNotifications,
+		OrderValue:                      d.OrderValue,
+		Currency:                        d.Currency,
+		DeliveryStatus:                  status,
+		CancellationReason:              d.CancellationReason,
+		PickupTimeEstimated:             d.PickupTimeEstimated,
+		PickupTimeActual:                d.PickupTimeActual,
+		DropoffTimeEstimated:            d.DropoffTimeEstimated,
+		DropoffTimeActual:               d.DropoffTimeActual,
+		Fee:                             d.Fee,
+		TrackingURL:                     d.TrackingURL,
+		Tip:                             d.Tip,
+	}
+}
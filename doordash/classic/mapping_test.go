@@ -0,0 +1,44 @@
+package classic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToNewDelivery(t *testing.T) {
+	pickup := time.Date(2018, 8, 22, 17, 0, 0, 0, time.UTC)
+	nd := ToNewDelivery(&DeliveryRequest{
+		ExternalDeliveryID: "D-12345",
+		PickupAddress:      Address{Street: "901 Market Street", Unit: "6th Floor", City: "San Francisco", State: "CA", ZipCode: "94103"},
+		DropoffAddress:     Address{Street: "1 Dr Carlton B Goodlett Pl", City: "San Francisco", State: "CA", ZipCode: "94102"},
+		Customer:           Customer{PhoneNumber: "+16505555555", FirstName: "John", LastName: "Doe"},
+		OrderValue:         1999,
+		PickupTime:         &pickup,
+		ExternalStoreID:    "S-1",
+	})
+
+	if nd.PickupAddress != "901 Market Street 6th Floor, San Francisco, CA 94103" {
+		t.Errorf("unexpected pickup address %q", nd.PickupAddress)
+	}
+	if nd.DropoffContactGivenName != "John" || nd.DropoffPhoneNumber != "+16505555555" || nd.PickupExternalStoreID != "S-1" {
+		t.Errorf("unexpected delivery %+v", nd)
+	}
+	if !nd.PickupTime.Equal(pickup) || !nd.DropoffTime.IsZero() {
+		t.Errorf("unexpected times %v, %v", nd.PickupTime, nd.DropoffTime)
+	}
+}
+
+func TestToDeliveryInfo(t *testing.T) {
+	d := &Delivery{}
+	json.Unmarshal(deliveryResponse, d)
+
+	info := ToDeliveryInfo(d)
+	if info.DeliveryStatus != "confirmed" || info.Fee != 975 || info.DropoffAddress != "1 Dr Carlton B Goodlett Pl, San Francisco, CA 94102" {
+		t.Errorf("unexpected delivery info %+v", info)
+	}
+	d.Status = "enroute_to_dropoff"
+	if info := ToDeliveryInfo(d); info.DeliveryStatus != "enroute_to_dropoff" {
+		t.Errorf("expected status to be kept, got %s", info.DeliveryStatus)
+	}
+}
@@ -3,12 +3,14 @@ package doordash
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,22 +23,91 @@ type (
 		BaseURL *url.URL
 		token   string
 		client  *http.Client
+
+		// Signs requests with short-lived JWTs instead of using token
+		credentials CredentialProvider
+		jwt         jwtCache
+
+		environment Environment
+		// Businesses and stores verified to belong to environment
+		verifiedBusinesses sync.Map
+		verifiedStores     sync.Map
+
+		strictDecoding    bool
+		unknownFieldsHook func(*UnknownFieldsError)
+
+		middleware []Middleware
+
+		serviceability serviceabilityCache
+	}
+
+	// Error returned when the API responds with a non-2xx status code
+	Error struct {
+		StatusCode  int          `json:"-"`
+		RequestID   string       `json:"-"`
+		Code        string       `json:"code"`
+		Message     string       `json:"message"`
+		FieldErrors []FieldError `json:"field_errors"`
+	}
+
+	// Validation failure for a single request field
+	FieldError struct {
+		Field string `json:"field"`
+		Error string `json:"error"`
 	}
 )
 
-// TODO: Refactor accessKey to a JSON string and process during client creation
-func NewClient(token string) *Client {
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("doordash: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	for _, f := range e.FieldErrors {
+		msg += fmt.Sprintf(" (%s: %s)", f.Field, f.Error)
+	}
+	return msg
+}
+
+// Option configuring a Client at creation
+type ClientOption func(*Client)
+
+// WithHTTPClient makes the client send requests through hc, e.g. to share a
+// transport and its connection pool between clients
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = hc
+	}
+}
+
+func NewClient(token string, opts ...ClientOption) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
-	return &Client{
+	c := &Client{
 		BaseURL: baseURL,
 		token:   token,
 		client: &http.Client{
 			Timeout: time.Minute,
 		},
 	}
+	c.serviceability.ttl = DefaultServiceabilityTTL
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *Client) NewRequest(method string, subPath string, body interface{}) (*http.Request, error) {
+	return c.newRequest(context.Background(), method, subPath, body)
+}
+
+// NewRequestWithContext is NewRequest for a request bound to ctx
+func (c *Client) NewRequestWithContext(ctx context.Context, method string, subPath string, body interface{}) (*http.Request, error) {
+	return c.newRequest(ctx, method, subPath, body)
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, subPath string, body interface{}) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
@@ -57,46 +128,138 @@ func (c *Client) NewRequest(method string, subPath string, body interface{}) (*h
 		}
 	}
 
-	req, err := http.NewRequest(method, url.String(), buf)
+	token, keyID, err := c.authorization()
+	if err != nil {
+		return nil, err
+	}
+	if keyID != "" {
+		ctx = context.WithValue(ctx, keyIDContextKey{}, keyID)
+	}
+	ctx = withEnvironment(ctx, c.environment)
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header = http.Header{
-		"Authorization": []string{"Bearer " + c.token},
+		"Authorization": []string{"Bearer " + token},
 		"Content-Type":  []string{"application/json"},
 	}
 
 	return req, nil
 }
 
-func (c *Client) Do(req *http.Request, v interface{}) error {
-	res, err := c.client.Do(req)
+// Do sends req through the client's middleware and decodes a successful
+// JSON response into v. Non-2xx responses are returned as *Error. A context
+// given with WithContext replaces the request's own.
+func (c *Client) Do(req *http.Request, v interface{}, opts ...CallOption) error {
+	cfg := newCallConfig(opts)
+	if cfg.ctx != nil {
+		req = req.WithContext(cfg.ctx)
+	}
+	return c.do(&Call{Operation: cfg.operation, Request: req, Result: v}, cfg)
+}
+
+func (c *Client) do(call *Call, cfg *callConfig) error {
+	call.Environment = RequestEnvironment(call.Request)
+	next := c.send
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+
+	err := next(call)
+	if cfg.capture != nil && call.Response != nil {
+		*cfg.capture = *call.Response
+		if !cfg.rawBody {
+			cfg.capture.Body = nil
+		}
+	}
+	return err
+}
+
+// send is the innermost RoundTripFunc of every call
+func (c *Client) send(call *Call) error {
+	call.attempts++
+	if call.attempts > 1 && call.Request.GetBody != nil {
+		// Middleware is retrying; the previous attempt consumed the body
+		body, err := call.Request.GetBody()
+		if err != nil {
+			return err
+		}
+		call.Request.Body = body
+	}
+	start := time.Now()
+	res, err := c.client.Do(call.Request)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if v != nil {
-		decErr := json.NewDecoder(res.Body).Decode(v)
+	body, err := io.ReadAll(res.Body)
+	call.Response = newResponse(call.Request, res, body, time.Since(start), call.attempts)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		apiErr := &Error{StatusCode: res.StatusCode, RequestID: call.Response.RequestID}
+		json.Unmarshal(body, apiErr) // error bodies are best effort
+		return apiErr
+	}
+
+	if call.Result != nil {
+		decErr := json.NewDecoder(bytes.NewReader(body)).Decode(call.Result)
 		if decErr == io.EOF {
 			decErr = nil // ignore EOF errors caused by empty response body
 		}
 		if decErr != nil {
-			err = decErr
+			return decErr
 		}
 	}
 
-	return err
+	return c.checkUnknownFields(call.Result)
+}
+
+// WithContext binds a call to ctx, for methods that do not take a context.
+// Cancelling ctx aborts the call, including any environment check it makes.
+func WithContext(ctx context.Context) CallOption {
+	return func(cfg *callConfig) {
+		cfg.ctx = ctx
+	}
+}
+
+// callContext returns the context given with WithContext, if any
+func callContext(opts []CallOption) context.Context {
+	if cfg := newCallConfig(opts); cfg.ctx != nil {
+		return cfg.ctx
+	}
+	return context.Background()
+}
+
+func (c *Client) makeRequest(operation string, method string, endpoint string, params url.Values, body interface{}, res interface{}, opts ...CallOption) error {
+	return c.makeRequestContext(callContext(opts), operation, method, endpoint, params, body, res, opts...)
 }
 
-func (c *Client) makeRequest(method string, endpoint string, params url.Values, body interface{}, res interface{}) error {
-	req, err := c.NewRequest(method, endpoint, body)
+func (c *Client) makeRequestContext(ctx context.Context, operation string, method string, endpoint string, params url.Values, body interface{}, res interface{}, opts ...CallOption) error {
+	req, err := c.newRequest(ctx, method, endpoint, body)
 	if err != nil {
 		return err
 	}
 
-	if err = c.Do(req, res); err != nil {
+	// Drop empty filters so optional parameters can be passed as ""
+	query := req.URL.Query()
+	for key, values := range params {
+		for _, v := range values {
+			if v != "" {
+				query.Add(key, v)
+			}
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+
+	call := &Call{Operation: operation, Request: req, Body: body, Result: res}
+	if err = c.do(call, newCallConfig(opts)); err != nil {
 		return err
 	}
 
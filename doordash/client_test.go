@@ -1,6 +1,8 @@
 package doordash
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -59,7 +61,7 @@ func TestDo(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	c := &Client{url, "token", server.Client()}
+	c := &Client{BaseURL: url, token: "token", client: server.Client()}
 	req, err := c.NewRequest("GET", "/foo", nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
@@ -73,3 +75,68 @@ func TestDo(t *testing.T) {
 		t.Errorf("Response body = %v, want %v", body, want)
 	}
 }
+
+func TestDoErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{
+			"code": "validation_error",
+			"message": "Validation Failed",
+			"field_errors": [{"field": "dropoff_address", "error": "Invalid address"}]
+		}`))
+	}))
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := &Client{BaseURL: url, token: "token", client: server.Client()}
+	req, _ := c.NewRequest("GET", "/foo", nil)
+
+	err := c.Do(req, &TestStruct{})
+	want := &Error{
+		StatusCode:  http.StatusBadRequest,
+		Code:        "validation_error",
+		Message:     "Validation Failed",
+		FieldErrors: []FieldError{{Field: "dropoff_address", Error: "Invalid address"}},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("expected error to be %v, got %v", want, err)
+	}
+}
+
+func TestNewClientWithHTTPClient(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClient("token", WithHTTPClient(hc))
+	if c.client != hc {
+		t.Error("expected client to use the given http.Client")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.Write(deliveryResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithHTTPClient(server.Client()))
+	c.BaseURL = url
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := c.GetDeliveryStatus("D-12345", WithContext(ctx)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	cancel()
+	if _, err := c.GetDeliveryStatus("D-12345", WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+	req, _ := c.NewRequest("GET", "drive/v2/deliveries/D-12345", nil)
+	if err := c.Do(req, &DeliveryInfo{}, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error from Do, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected the cancelled calls not to be sent, got %d requests", requests)
+	}
+}
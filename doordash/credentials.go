@@ -0,0 +1,226 @@
+// API Doc: https://developer.doordash.com/en-US/docs/drive/how_to/JWTs
+package doordash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	jwtLifetime = 5 * time.Minute
+	// Tokens are renewed this long before they expire
+	jwtRenewBefore = 30 * time.Second
+)
+
+// Drive API access key as created in the Developer Portal
+type Credentials struct {
+	DeveloperID   string `json:"developer_id"`
+	KeyID         string `json:"key_id"`
+	SigningSecret string `json:"signing_secret"`
+}
+
+// Source of the credentials used to sign requests. Providers are asked on
+// every request, so rotated keys are picked up without restarting.
+type CredentialProvider interface {
+	Credentials() (*Credentials, error)
+}
+
+// Adapter to use an ordinary function as a CredentialProvider
+type CredentialProviderFunc func() (*Credentials, error)
+
+func (f CredentialProviderFunc) Credentials() (*Credentials, error) {
+	return f()
+}
+
+// StaticCredentials always provides the same credentials
+func StaticCredentials(creds Credentials) CredentialProvider {
+	return CredentialProviderFunc(func() (*Credentials, error) {
+		return &creds, nil
+	})
+}
+
+// EnvCredentials reads credentials from the DOORDASH_DEVELOPER_ID,
+// DOORDASH_KEY_ID and DOORDASH_SIGNING_SECRET environment variables
+func EnvCredentials() CredentialProvider {
+	return CredentialProviderFunc(func() (*Credentials, error) {
+		creds := &Credentials{
+			DeveloperID:   os.Getenv("DOORDASH_DEVELOPER_ID"),
+			KeyID:         os.Getenv("DOORDASH_KEY_ID"),
+			SigningSecret: os.Getenv("DOORDASH_SIGNING_SECRET"),
+		}
+		return creds, creds.validate()
+	})
+}
+
+// FileCredentials reads a Developer Portal JSON key file once
+func FileCredentials(path string) (CredentialProvider, error) {
+	creds, err := readCredentialsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return StaticCredentials(*creds), nil
+}
+
+// WatchedFileCredentials reads a Developer Portal JSON key file and reloads
+// it whenever it changes on disk. If a changed file cannot be read, the
+// error is returned until the file is fixed rather than silently signing
+// with the old key.
+func WatchedFileCredentials(path string) (CredentialProvider, error) {
+	w := &watchedFile{path: path}
+	if _, err := w.Credentials(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+type watchedFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	creds   *Credentials
+}
+
+func (w *watchedFile) Credentials() (*Credentials, error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.creds != nil && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return w.creds, nil
+	}
+
+	creds, err := readCredentialsFile(w.path)
+	if err != nil {
+		return nil, err
+	}
+	w.creds, w.modTime, w.size = creds, info.ModTime(), info.Size()
+	return creds, nil
+}
+
+// WithCredentials signs every request with a JWT made from the provider's
+// credentials instead of the client's static token
+func WithCredentials(p CredentialProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = p
+	}
+}
+
+// NewClientWithCredentials returns a client that signs its requests with
+// credentials from p
+func NewClientWithCredentials(p CredentialProvider, opts ...ClientOption) *Client {
+	return NewClient("", append([]ClientOption{WithCredentials(p)}, opts...)...)
+}
+
+type keyIDContextKey struct{}
+
+// SigningKeyID returns the ID of the key that signed a request made by a
+// client with credentials, or "" for requests using a static token. It can
+// be used from a custom http.Client transport to log or audit key usage.
+func SigningKeyID(req *http.Request) string {
+	id, _ := req.Context().Value(keyIDContextKey{}).(string)
+	return id
+}
+
+func (creds *Credentials) validate() error {
+	var missing []string
+	if creds.DeveloperID == "" {
+		missing = append(missing, "developer_id")
+	}
+	if creds.KeyID == "" {
+		missing = append(missing, "key_id")
+	}
+	if creds.SigningSecret == "" {
+		missing = append(missing, "signing_secret")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("credentials missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func readCredentialsFile(path string) (*Credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	creds := &Credentials{}
+	if err := json.Unmarshal(data, creds); err != nil {
+		return nil, fmt.Errorf("reading credentials %s: %v", path, err)
+	}
+	if err := creds.validate(); err != nil {
+		return nil, fmt.Errorf("reading credentials %s: %v", path, err)
+	}
+	return creds, nil
+}
+
+// Signed token cached until shortly before it expires or the credentials
+// change
+type jwtCache struct {
+	mu      sync.Mutex
+	creds   Credentials
+	token   string
+	expires time.Time
+}
+
+// authorization returns the bearer token for the next request and the ID of
+// the key that signed it, if any
+func (c *Client) authorization() (string, string, error) {
+	if c.credentials == nil {
+		return c.token, "", nil
+	}
+
+	creds, err := c.credentials.Credentials()
+	if err != nil {
+		return "", "", err
+	}
+
+	c.jwt.mu.Lock()
+	defer c.jwt.mu.Unlock()
+	now := time.Now()
+	if c.jwt.token == "" || c.jwt.creds != *creds || now.After(c.jwt.expires.Add(-jwtRenewBefore)) {
+		token, err := signJWT(creds, now)
+		if err != nil {
+			return "", "", err
+		}
+		c.jwt.creds, c.jwt.token, c.jwt.expires = *creds, token, now.Add(jwtLifetime)
+	}
+	return c.jwt.token, creds.KeyID, nil
+}
+
+func signJWT(creds *Credentials, now time.Time) (string, error) {
+	secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(creds.SigningSecret, "="))
+	if err != nil {
+		return "", fmt.Errorf("signing secret is not base64url encoded: %v", err)
+	}
+
+	header, _ := json.Marshal(map[string]string{
+		"alg":    "HS256",
+		"typ":    "JWT",
+		"dd-ver": "DD-JWT-V1",
+	})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"aud": "doordash",
+		"iss": creds.DeveloperID,
+		"kid": creds.KeyID,
+		"iat": now.Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
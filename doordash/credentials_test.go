@@ -0,0 +1,135 @@
+package doordash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = base64.RawURLEncoding.EncodeToString([]byte("super-secret-signing-key"))
+
+// Transport recording the key ID of every request it sends
+type keyIDRecorder struct {
+	next http.RoundTripper
+	ids  []string
+}
+
+func (r *keyIDRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.ids = append(r.ids, SigningKeyID(req))
+	return r.next.RoundTrip(req)
+}
+
+func TestClientWithCredentials(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		tokens = append(tokens, strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+		// Send response to be tested
+		rw.Write(businessResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	creds := Credentials{DeveloperID: "dev-1", KeyID: "key-1", SigningSecret: testSecret}
+	provider := CredentialProviderFunc(func() (*Credentials, error) {
+		c := creds
+		return &c, nil
+	})
+	recorder := &keyIDRecorder{next: server.Client().Transport}
+
+	client := NewClientWithCredentials(provider, WithHTTPClient(&http.Client{Transport: recorder}))
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	client.GetBusiness("B-12345")
+	client.GetBusiness("B-12345")
+	creds.KeyID = "key-2"
+	client.GetBusiness("B-12345")
+
+	if len(tokens) != 3 || tokens[0] != tokens[1] || tokens[1] == tokens[2] {
+		t.Fatalf("expected cached token to be reused until the key rotates, got %v", tokens)
+	}
+	if got := strings.Join(recorder.ids, ","); got != "key-1,key-1,key-2" {
+		t.Errorf("expected signing key IDs key-1,key-1,key-2, got %s", got)
+	}
+
+	// Verify the token's signature and claims
+	parts := strings.Split(tokens[2], ".")
+	mac := hmac.New(sha256.New, []byte("super-secret-signing-key"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Error("expected token to be signed with the signing secret")
+	}
+
+	header, claims := map[string]interface{}{}, map[string]interface{}{}
+	data, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	json.Unmarshal(data, &header)
+	data, _ = base64.RawURLEncoding.DecodeString(parts[1])
+	json.Unmarshal(data, &claims)
+	if header["alg"] != "HS256" || header["dd-ver"] != "DD-JWT-V1" {
+		t.Errorf("unexpected JWT header %v", header)
+	}
+	if claims["aud"] != "doordash" || claims["iss"] != "dev-1" || claims["kid"] != "key-2" {
+		t.Errorf("unexpected JWT claims %v", claims)
+	}
+}
+
+func TestSigningKeyIDWithStaticToken(t *testing.T) {
+	c := NewClient("token")
+	req, _ := c.NewRequest("GET", "/foo", nil)
+	if id := SigningKeyID(req); id != "" {
+		t.Errorf("expected no key ID for a static token, got %q", id)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("DOORDASH_DEVELOPER_ID", "dev-1")
+	t.Setenv("DOORDASH_KEY_ID", "key-1")
+	t.Setenv("DOORDASH_SIGNING_SECRET", testSecret)
+
+	creds, err := EnvCredentials().Credentials()
+	if err != nil || creds.KeyID != "key-1" {
+		t.Errorf("expected credentials from the environment, got %v, %v", creds, err)
+	}
+
+	t.Setenv("DOORDASH_KEY_ID", "")
+	if _, err := EnvCredentials().Credentials(); err == nil {
+		t.Error("expected error for missing key ID, got nil")
+	}
+}
+
+func TestWatchedFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.json")
+	write := func(keyID string, modTime time.Time) {
+		os.WriteFile(path, []byte(`{"developer_id": "dev-1", "key_id": "`+keyID+`", "signing_secret": "`+testSecret+`"}`), 0600)
+		os.Chtimes(path, modTime, modTime)
+	}
+
+	t0 := time.Date(2022, 4, 25, 17, 0, 0, 0, time.UTC)
+	write("key-1", t0)
+	provider, err := WatchedFileCredentials(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	write("key-2", t0.Add(time.Minute))
+	if creds, _ := provider.Credentials(); creds.KeyID != "key-2" {
+		t.Errorf("expected rotated key to be loaded, got %s", creds.KeyID)
+	}
+
+	os.WriteFile(path, []byte(`{"developer_id": "dev-1"}`), 0600)
+	if _, err := provider.Credentials(); err == nil {
+		t.Error("expected error for an incomplete key file, got nil")
+	}
+
+	if _, err := FileCredentials(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for a missing key file, got nil")
+	}
+}
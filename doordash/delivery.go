@@ -2,6 +2,7 @@
 package doordash
 
 import (
+	"encoding/json"
 	"net/url"
 	"time"
 )
@@ -35,6 +36,34 @@ type NewDelivery struct {
 	Tip                             int        `json:"tip"`
 }
 
+// MarshalJSON leaves out unset pickup and dropoff times and windows instead
+// of sending the zero time, which the API would reject
+func (d NewDelivery) MarshalJSON() ([]byte, error) {
+	type plain NewDelivery
+	// The fields declared here shadow the embedded ones of the same name
+	return json.Marshal(struct {
+		plain
+		PickupTime    *time.Time  `json:"pickup_time,omitempty"`
+		DropoffTime   *time.Time  `json:"dropoff_time,omitempty"`
+		PickupWindow  *TimeWindow `json:"pickup_window,omitempty"`
+		DropoffWindow *TimeWindow `json:"dropoff_window,omitempty"`
+	}{plain(d), timeOrNil(d.PickupTime), timeOrNil(d.DropoffTime), windowOrNil(d.PickupWindow), windowOrNil(d.DropoffWindow)})
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func windowOrNil(w TimeWindow) *TimeWindow {
+	if w == (TimeWindow{}) {
+		return nil
+	}
+	return &w
+}
+
 // Object for sending a delivery update
 type DeliveryUpdate struct {
 	PickupAddress                   string    `json:"pickup_address"`
@@ -105,6 +134,23 @@ type DeliveryInfo struct {
 	ContactlessDropoff              bool      `json:"contactless_dropoff"`
 	ActionIfUndeliverable           string    `json:"action_if_undeliverable"`
 	Tip                             int       `json:"tip"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (d *DeliveryInfo) UnmarshalJSON(data []byte) error {
+	type plain DeliveryInfo
+	return unmarshalWithExtra(data, (*plain)(d), &d.Extra)
+}
+
+func (d DeliveryInfo) MarshalJSON() ([]byte, error) {
+	type plain DeliveryInfo
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+func (d *DeliveryInfo) unknownFields() []string {
+	return extraKeys("", d.Extra)
 }
 
 type TimeWindow struct {
@@ -113,31 +159,34 @@ type TimeWindow struct {
 }
 
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/CreateDelivery
-func (c *Client) CreateDelivery(d *NewDelivery) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("POST", "drive/v2/deliveries", d)
+func (c *Client) CreateDelivery(d *NewDelivery, opts ...CallOption) (*DeliveryInfo, error) {
+	if err := c.checkStore(callContext(opts), d.PickupExternalBusinessID, d.PickupExternalStoreID); err != nil {
+		return nil, err
+	}
+	return c.makeDeliveryRequest("CreateDelivery", "POST", "drive/v2/deliveries", d, opts...)
 }
 
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/GetDelivery
-func (c *Client) GetDeliveryStatus(externalDeliveryID string) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("GET", ("drive/v2/deliveries/" + externalDeliveryID), nil)
+func (c *Client) GetDeliveryStatus(externalDeliveryID string, opts ...CallOption) (*DeliveryInfo, error) {
+	return c.makeDeliveryRequest("GetDeliveryStatus", "GET", ("drive/v2/deliveries/" + externalDeliveryID), nil, opts...)
 }
 
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/UpdateDelivery
-func (c *Client) UpdateDelivery(externalDeliveryID string, d *DeliveryUpdate) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("PATCH", ("drive/v2/deliveries/" + externalDeliveryID), d)
+func (c *Client) UpdateDelivery(externalDeliveryID string, d *DeliveryUpdate, opts ...CallOption) (*DeliveryInfo, error) {
+	return c.makeDeliveryRequest("UpdateDelivery", "PATCH", ("drive/v2/deliveries/" + externalDeliveryID), d, opts...)
 }
 
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/CancelDelivery
-func (c *Client) CancelDelivery(externalDeliveryID string) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("PUT", ("drive/v2/deliveries/" + externalDeliveryID), nil)
+func (c *Client) CancelDelivery(externalDeliveryID string, opts ...CallOption) (*DeliveryInfo, error) {
+	return c.makeDeliveryRequest("CancelDelivery", "PUT", ("drive/v2/deliveries/" + externalDeliveryID), nil, opts...)
 
 }
 
-func (c *Client) makeDeliveryRequest(method string, endpoint string, body interface{}) (*DeliveryInfo, error) {
+func (c *Client) makeDeliveryRequest(operation string, method string, endpoint string, body interface{}, opts ...CallOption) (*DeliveryInfo, error) {
 	var params url.Values
 
 	res := &DeliveryInfo{}
-	err := c.makeRequest(method, endpoint, params, body, res)
+	err := c.makeRequest(operation, method, endpoint, params, body, res, opts...)
 	if err != nil {
 		return nil, err
 	}
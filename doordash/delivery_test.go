@@ -74,7 +74,7 @@ func TestCreateDelivery(t *testing.T) {
 		OrderValue:          1999,
 	}
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.CreateDelivery(payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -102,7 +102,7 @@ func TestGetDeliveryStatus(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.GetDeliveryStatus(testID)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -162,7 +162,7 @@ func TestUpdateDelivery(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.UpdateDelivery(testID, payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -190,7 +190,7 @@ func TestCancelDelivery(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.CancelDelivery(testID)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -0,0 +1,150 @@
+// Sandbox and production environment selection
+package doordash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DoorDash environment a client's credentials belong to. Sandbox and
+// production share the same API endpoint; what differs is whether the
+// businesses and stores the credentials can see are test ones.
+type Environment string
+
+const (
+	// No environment checks are made
+	EnvironmentUnspecified Environment = ""
+	Sandbox                Environment = "sandbox"
+	Production             Environment = "production"
+)
+
+// Returned when a business or store does not belong to the client's
+// environment, e.g. a test store seen by a production client
+type EnvironmentMismatchError struct {
+	Environment Environment
+	// "business" or "store"
+	Resource string
+	ID       string
+	IsTest   bool
+	// The API had already applied the change when the mismatch was found,
+	// e.g. for a newly created business, which cannot be checked beforehand.
+	// The result is returned alongside the error and retrying would
+	// duplicate the change.
+	Written bool
+}
+
+func (e *EnvironmentMismatchError) Error() string {
+	kind := "live"
+	if e.IsTest {
+		kind = "test"
+	}
+	if e.Written {
+		return fmt.Sprintf("doordash: %s client wrote to %s %s %s", e.Environment, kind, e.Resource, e.ID)
+	}
+	return fmt.Sprintf("doordash: %s client refused %s %s %s", e.Environment, kind, e.Resource, e.ID)
+}
+
+// WithEnvironment marks the client as sandbox or production. Businesses and
+// stores returned by the API are then checked against it, and writes are
+// only made to businesses and stores in the same environment: deliveries and
+// quotes for their pickup store, store creation for its business, and
+// updates for their target. Requests are tagged with the environment for
+// middleware (Call.Environment), transports (RequestEnvironment) and
+// response capture (Response.Environment).
+func WithEnvironment(env Environment) ClientOption {
+	return func(c *Client) {
+		c.environment = env
+	}
+}
+
+// Environment returns the environment the client was marked with
+func (c *Client) Environment() Environment {
+	return c.environment
+}
+
+type environmentContextKey struct{}
+
+// RequestEnvironment returns the environment of the client that made a
+// request, so custom transports can tag logs and metrics with it.
+// Middleware gets the same value as Call.Environment.
+func RequestEnvironment(req *http.Request) Environment {
+	env, _ := req.Context().Value(environmentContextKey{}).(Environment)
+	return env
+}
+
+func withEnvironment(ctx context.Context, env Environment) context.Context {
+	if env == EnvironmentUnspecified {
+		return ctx
+	}
+	return context.WithValue(ctx, environmentContextKey{}, env)
+}
+
+// checkEnvironment verifies a business or store returned by the API belongs
+// to the client's environment
+func (c *Client) checkEnvironment(resource string, id string, isTest bool) error {
+	if c.environment == EnvironmentUnspecified || isTest == (c.environment == Sandbox) {
+		return nil
+	}
+	return &EnvironmentMismatchError{Environment: c.environment, Resource: resource, ID: id, IsTest: isTest}
+}
+
+// checkWritten verifies a business or store returned by a write belongs to
+// the client's environment. The change has been made by then, so a mismatch
+// is reported as Written.
+func (c *Client) checkWritten(resource string, id string, isTest bool) error {
+	err := c.checkEnvironment(resource, id, isTest)
+	if mismatch, ok := err.(*EnvironmentMismatchError); ok {
+		mismatch.Written = true
+	}
+	return err
+}
+
+// checkBusiness verifies a business belongs to the client's environment
+// before writing to it. Verified businesses are remembered so only the first
+// write costs an extra request.
+func (c *Client) checkBusiness(ctx context.Context, externalBusinessID string) error {
+	if c.environment == EnvironmentUnspecified || externalBusinessID == "" {
+		return nil
+	}
+	if _, ok := c.verifiedBusinesses.Load(externalBusinessID); ok {
+		return nil
+	}
+
+	business := &BusinessInfo{}
+	if err := c.makeRequestContext(ctx, "GetBusiness", "GET", "/developer/v1/businesses/"+externalBusinessID, nil, nil, business); err != nil {
+		return err
+	}
+	if err := c.checkEnvironment("business", externalBusinessID, business.IsTest); err != nil {
+		return err
+	}
+	c.verifiedBusinesses.Store(externalBusinessID, true)
+	return nil
+}
+
+// checkStore verifies a store belongs to the client's environment before
+// writing to it or using it as the pickup store of a delivery or quote.
+// Verified stores are remembered so only the first write costs an extra
+// request. Deliveries without a pickup store reference cannot be checked and
+// are let through.
+func (c *Client) checkStore(ctx context.Context, externalBusinessID string, externalStoreID string) error {
+	if c.environment == EnvironmentUnspecified || externalBusinessID == "" || externalStoreID == "" {
+		return nil
+	}
+
+	key := externalBusinessID + "/" + externalStoreID
+	if _, ok := c.verifiedStores.Load(key); ok {
+		return nil
+	}
+
+	store := &StoreInfo{}
+	endpoint := "/developer/v1/businesses/" + externalBusinessID + "/stores/" + externalStoreID
+	if err := c.makeRequestContext(ctx, "GetStore", "GET", endpoint, nil, nil, store); err != nil {
+		return err
+	}
+	if err := c.checkEnvironment("store", externalStoreID, store.IsTest); err != nil {
+		return err
+	}
+	c.verifiedStores.Store(key, true)
+	return nil
+}
@@ -0,0 +1,135 @@
+package doordash
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestEnvironmentGuard(t *testing.T) {
+	var paths []string
+	var envs []Environment
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		// Send response to be tested
+		switch req.URL.Path {
+		case "/developer/v1/businesses/B-12345":
+			rw.Write(businessResponse)
+		case "/developer/v1/businesses/B-12345/stores/S-12345":
+			rw.Write(storeResponse)
+		default:
+			rw.Write(deliveryResponse)
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	newClient := func(env Environment) *Client {
+		transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			envs = append(envs, RequestEnvironment(req))
+			return server.Client().Transport.RoundTrip(req)
+		})
+		c := NewClient("token", WithEnvironment(env), WithHTTPClient(&http.Client{Transport: transport}))
+		c.BaseURL = url
+		return c
+	}
+	delivery := &NewDelivery{ExternalDeliveryID: "D-12345", PickupExternalBusinessID: "B-12345", PickupExternalStoreID: "S-12345"}
+
+	// The fixtures are live (is_test: false) business and store
+	sandbox := newClient(Sandbox)
+	if _, err := sandbox.GetBusiness("B-12345"); err == nil {
+		t.Error("expected sandbox client to refuse a live business, got nil")
+	}
+	_, err := sandbox.CreateDelivery(delivery)
+	if mismatch, ok := err.(*EnvironmentMismatchError); !ok || mismatch.Resource != "store" || mismatch.IsTest {
+		t.Errorf("expected environment mismatch for a live store, got %v", err)
+	}
+	if paths[len(paths)-1] != "/developer/v1/businesses/B-12345/stores/S-12345" {
+		t.Errorf("expected delivery not to be created, last request was %s", paths[len(paths)-1])
+	}
+	store := &StoreInfo{ExternalBusinessID: "B-12345", ExternalStoreID: "S-12345", Address: "901 Market Street, San Francisco"}
+	_, err = sandbox.CheckServiceability(context.Background(), store, "1 Dr Carlton B Goodlett Pl, San Francisco")
+	if _, ok := err.(*EnvironmentMismatchError); !ok {
+		t.Errorf("expected environment mismatch for a serviceability check against a live store, got %v", err)
+	}
+	if paths[len(paths)-1] == "/drive/v2/quotes" {
+		t.Error("expected serviceability quote not to be requested")
+	}
+
+	paths = nil
+	production := newClient(Production)
+	for i := 0; i < 2; i++ {
+		if _, err := production.CreateDelivery(delivery); err != nil {
+			t.Fatalf("expected error to be nil, got %v", err)
+		}
+	}
+	want := []string{"/developer/v1/businesses/B-12345/stores/S-12345", "/drive/v2/deliveries", "/drive/v2/deliveries"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[2] != want[2] {
+		t.Errorf("expected store to be verified once, got requests %v", paths)
+	}
+	if production.Environment() != Production || envs[len(envs)-1] != Production {
+		t.Errorf("expected requests tagged with production, got %v", envs)
+	}
+}
+
+func TestEnvironmentGuardWrites(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		// Send response to be tested
+		if strings.Contains(req.URL.Path, "/stores") {
+			rw.Write(storeResponse)
+		} else {
+			rw.Write(businessResponse)
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	var tagged []Environment
+	url, _ := url.Parse(server.URL + "/")
+	sandbox := NewClient("token", WithEnvironment(Sandbox), WithHTTPClient(server.Client()), WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(call *Call) error {
+			tagged = append(tagged, call.Environment)
+			return next(call)
+		}
+	}))
+	sandbox.BaseURL = url
+
+	// The fixtures are live (is_test: false) business and store
+	if _, err := sandbox.UpdateStore("B-12345", "S-12345", &StoreUpdate{Name: "Deli"}); err == nil {
+		t.Error("expected sandbox client to refuse updating a live store, got nil")
+	}
+	if _, err := sandbox.CreateStore("B-12345", &NewStore{ExternalStoreID: "S-2"}); err == nil {
+		t.Error("expected sandbox client to refuse creating a store for a live business, got nil")
+	}
+	if _, err := sandbox.UpdateBusiness("B-12345", &BusinessUpdate{Name: "Deli"}); err == nil {
+		t.Error("expected sandbox client to refuse updating a live business, got nil")
+	}
+	for _, r := range requests {
+		if !strings.HasPrefix(r, "GET ") {
+			t.Errorf("expected refused writes not to be sent, got %s", r)
+		}
+	}
+
+	// A new business can only be checked once it exists
+	b, err := sandbox.CreateBusiness(&NewBusiness{ExternalBusinessID: "B-12345"})
+	mismatch, ok := err.(*EnvironmentMismatchError)
+	if !ok || !mismatch.Written || b == nil || b.ExternalBusinessID != mismatch.ID {
+		t.Errorf("expected created business along with a written mismatch, got %v, %v", b, err)
+	}
+
+	if len(tagged) == 0 || tagged[0] != Sandbox {
+		t.Errorf("expected middleware to see the environment, got %v", tagged)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
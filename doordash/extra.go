@@ -0,0 +1,160 @@
+// Preservation of response fields not yet known to the SDK
+package doordash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Returned by clients created with WithStrictDecoding, and passed to the
+// hook set with WithUnknownFieldsHook, when a response contains fields the
+// SDK does not know. Fields of list items are prefixed with their position,
+// e.g. "result[0].new_field".
+type UnknownFieldsError struct {
+	// Name of the response type, e.g. "DeliveryInfo"
+	Type   string
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("doordash: unknown fields in %s response: %s", e.Type, strings.Join(e.Fields, ", "))
+}
+
+// WithStrictDecoding makes calls fail with *UnknownFieldsError when a
+// response contains fields the SDK does not know, so API changes are noticed
+// in CI. The decoded result is discarded.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithUnknownFieldsHook calls fn whenever a response contains fields the
+// SDK does not know, e.g. to log them. Calls still succeed unless strict
+// decoding is enabled as well.
+func WithUnknownFieldsHook(fn func(*UnknownFieldsError)) ClientOption {
+	return func(c *Client) {
+		c.unknownFieldsHook = fn
+	}
+}
+
+// Response types that keep unrecognized fields in Extra
+type unknownFielder interface {
+	unknownFields() []string
+}
+
+// checkUnknownFields applies the client's strict mode and hook to a decoded
+// response
+func (c *Client) checkUnknownFields(v interface{}) error {
+	if !c.strictDecoding && c.unknownFieldsHook == nil {
+		return nil
+	}
+	u, ok := v.(unknownFielder)
+	if !ok {
+		return nil
+	}
+	fields := u.unknownFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	err := &UnknownFieldsError{Type: reflect.Indirect(reflect.ValueOf(v)).Type().Name(), Fields: fields}
+	if c.unknownFieldsHook != nil {
+		c.unknownFieldsHook(err)
+	}
+	if c.strictDecoding {
+		return err
+	}
+	return nil
+}
+
+func extraKeys(prefix string, extra map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		keys = append(keys, prefix+k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// JSON names of the fields of each struct type, keyed by reflect.Type
+var knownFieldsCache sync.Map
+
+func knownFields(t reflect.Type) map[string]bool {
+	if known, ok := knownFieldsCache.Load(t); ok {
+		return known.(map[string]bool)
+	}
+	known := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		known[name] = true
+	}
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct without
+// custom JSON methods, and stores the fields v has no place for in extra
+func unmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	known := knownFields(reflect.TypeOf(v).Elem())
+	*extra = nil
+	for k, raw := range all {
+		if known[k] {
+			continue
+		}
+		if *extra == nil {
+			*extra = map[string]json.RawMessage{}
+		}
+		(*extra)[k] = raw
+	}
+	return nil
+}
+
+// marshalWithExtra encodes v, a struct without custom JSON methods, and
+// appends the extra fields in key order. Extra fields never override known
+// ones.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	empty := len(data) == 2
+	for _, k := range extraKeys("", extra) {
+		if known[k] {
+			continue
+		}
+		key, _ := json.Marshal(k)
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,82 @@
+package doordash
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestUnknownFieldsPreserved(t *testing.T) {
+	data := []byte(`{"external_delivery_id":"D-12345","fee":975,"dasher_vehicle":{"make":"Honda"},"batch_id":"B-1"}`)
+
+	d := &DeliveryInfo{}
+	if err := json.Unmarshal(data, d); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if d.Fee != 975 || len(d.Extra) != 2 || string(d.Extra["batch_id"]) != `"B-1"` {
+		t.Errorf("unexpected delivery %+v", d)
+	}
+
+	out, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if !strings.HasSuffix(string(out), `,"batch_id":"B-1","dasher_vehicle":{"make":"Honda"}}`) {
+		t.Errorf("expected extra fields to be re-marshalled, got %s", out)
+	}
+
+	known := &DeliveryInfo{}
+	json.Unmarshal([]byte(`{"external_delivery_id":"D-12345"}`), known)
+	if known.Extra != nil {
+		t.Errorf("expected no extra fields, got %v", known.Extra)
+	}
+
+	e := &DeliveryEvent{}
+	if err := json.Unmarshal([]byte(`{"event_name":"DASHER_CONFIRMED","external_delivery_id":"D-12345","batch_id":"B-1"}`), e); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if e.EventName != "DASHER_CONFIRMED" || e.ExternalDeliveryID != "D-12345" || len(e.Extra) != 1 {
+		t.Errorf("unexpected event %+v", e)
+	}
+	out, _ = json.Marshal(e)
+	if !strings.HasPrefix(string(out), `{"event_name":"DASHER_CONFIRMED",`) || !strings.Contains(string(out), `"batch_id":"B-1"`) {
+		t.Errorf("unexpected event JSON %s", out)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Send response to be tested
+		rw.Write([]byte(`{"result":[{"external_store_id":"S-1"},{"external_store_id":"S-2","rating":4.5}],"result_count":2}`))
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	var logged []*UnknownFieldsError
+	hook := WithUnknownFieldsHook(func(e *UnknownFieldsError) {
+		logged = append(logged, e)
+	})
+
+	lenient := NewClient("token", hook, WithHTTPClient(server.Client()))
+	lenient.BaseURL = url
+	stores, err := lenient.ListStores("B-1", "", "")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if string(stores.Result[1].Extra["rating"]) != "4.5" {
+		t.Errorf("expected rating to be kept, got %v", stores.Result[1].Extra)
+	}
+	if len(logged) != 1 || logged[0].Type != "StoreInfoList" || logged[0].Fields[0] != "result[1].rating" {
+		t.Errorf("expected unknown field to be reported, got %v", logged)
+	}
+
+	strict := NewClient("token", WithStrictDecoding(), WithHTTPClient(server.Client()))
+	strict.BaseURL = url
+	if _, err := strict.ListStores("B-1", "", ""); err == nil || !strings.Contains(err.Error(), "result[1].rating") {
+		t.Errorf("expected unknown fields error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package jsonfile persists in-memory stores as a single JSON file that is
+// rewritten atomically on every change.
+package jsonfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Load decodes the file at path into v. A missing file leaves v unchanged
+// and is not an error, so stores can start empty.
+func Load(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Write replaces the file at path with v encoded as indented JSON. The data
+// is written to a temporary file in the same directory and renamed over
+// path, so readers never see a partial file.
+func Write(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Commit writes v to path like Write and calls rollback if that fails, so a
+// store can undo the in-memory change and stay consistent with what is on
+// disk
+func Commit(path string, v interface{}, rollback func()) error {
+	if err := Write(path, v); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
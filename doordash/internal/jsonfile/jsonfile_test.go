@@ -0,0 +1,39 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	var got []string
+	if err := Load(path, &got); err != nil || got != nil {
+		t.Fatalf("expected missing file to load nothing, got %v, %v", got, err)
+	}
+
+	want := []string{"a", "b"}
+	if err := Write(path, want); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if err := Load(path, &got); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v, %v", want, got, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected temporary files to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestCommitRollsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "store.json")
+	rolledBack := false
+	if err := Commit(path, []string{"a"}, func() { rolledBack = true }); err == nil || !rolledBack {
+		t.Errorf("expected failed write to roll back, got %v", err)
+	}
+	if err := Commit(filepath.Join(t.TempDir(), "store.json"), []string{"a"}, func() { rolledBack = false }); err != nil || !rolledBack {
+		t.Errorf("expected successful write not to roll back, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+// Package webhook serves the webhooks of every DoorDash API the same way, so
+// their handlers answer malformed payloads and failures alike.
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Error marking a payload that could not be decoded, answered with 400
+type malformedError struct {
+	err error
+}
+
+func (e *malformedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *malformedError) Unwrap() error {
+	return e.err
+}
+
+// Malformed marks err as a decoding error of the payload
+func Malformed(err error) error {
+	return &malformedError{err: err}
+}
+
+// Handler returns an http.Handler passing the body of POST requests to fn.
+// Errors marked with Malformed are answered with 400 and other errors with
+// 500 so the sender retries the event.
+func Handler(fn func(body io.Reader) error) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := fn(req.Body); err != nil {
+			var malformed *malformedError
+			if errors.As(err, &malformed) {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+			} else {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func(body io.Reader) error {
+		data, _ := io.ReadAll(body)
+		switch string(data) {
+		case "malformed":
+			return Malformed(errors.New("unexpected EOF"))
+		case "fail":
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	for _, tc := range []struct {
+		method string
+		body   string
+		want   int
+	}{
+		{http.MethodPost, "ok", http.StatusOK},
+		{http.MethodPost, "malformed", http.StatusBadRequest},
+		{http.MethodPost, "fail", http.StatusInternalServerError},
+		{http.MethodGet, "ok", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tc.method, "/webhook", strings.NewReader(tc.body)))
+		if rec.Code != tc.want {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.body, tc.want, rec.Code)
+		}
+	}
+}
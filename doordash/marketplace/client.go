@@ -0,0 +1,31 @@
+// Package marketplace covers the DoorDash Marketplace APIs used by
+// restaurants selling on the DoorDash app: menus, orders and store status.
+// It shares the transport, authentication and middleware of a
+// doordash.Client.
+//
+// API Doc: https://developer.doordash.com/en-US/docs/marketplace/overview
+package marketplace
+
+import (
+	"context"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Marketplace client
+type Client struct {
+	client *doordash.Client
+}
+
+// New returns a Marketplace client sending its requests through c
+func New(c *doordash.Client) *Client {
+	return &Client{client: c}
+}
+
+func (c *Client) makeRequest(ctx context.Context, operation string, method string, endpoint string, body interface{}, res interface{}, opts []doordash.CallOption) error {
+	req, err := c.client.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	return c.client.Do(req, res, append([]doordash.CallOption{doordash.WithOperation("marketplace." + operation)}, opts...)...)
+}
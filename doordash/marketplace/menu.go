@@ -0,0 +1,134 @@
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#tag/Menu
+package marketplace
+
+import (
+	"context"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Store a menu belongs to, identified by the merchant's own ID
+type MenuStore struct {
+	MerchantSuppliedID string `json:"merchant_supplied_id"`
+	ProviderType       string `json:"provider_type,omitempty"`
+}
+
+// Object describing when a menu is available on a given day. DayIndex is
+// one of MON, TUE, WED, THU, FRI, SAT or SUN; times are local to the store
+// in 24-hour "HH:MM" format.
+type MenuHours struct {
+	DayIndex  string `json:"day_index"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// Object for pushing a full menu for a store
+type Menu struct {
+	// Caller's reference echoed back in the menu job callback
+	Reference string      `json:"reference,omitempty"`
+	Store     MenuStore   `json:"store"`
+	OpenHours []MenuHours `json:"open_hours"`
+	// Overrides for specific dates, e.g. holidays
+	SpecialHours []doordash.SpecialHours `json:"special_hours,omitempty"`
+	Menu         MenuContent             `json:"menu"`
+}
+
+type MenuContent struct {
+	Name       string     `json:"name"`
+	Subtitle   string     `json:"subtitle,omitempty"`
+	Active     bool       `json:"active"`
+	Categories []Category `json:"categories"`
+}
+
+type Category struct {
+	MerchantSuppliedID string `json:"merchant_supplied_id"`
+	Name               string `json:"name"`
+	Subtitle           string `json:"subtitle,omitempty"`
+	Active             bool   `json:"active"`
+	SortID             int    `json:"sort_id"`
+	Items              []Item `json:"items"`
+}
+
+// Menu item. Prices are in cents.
+type Item struct {
+	MerchantSuppliedID string        `json:"merchant_supplied_id"`
+	Name               string        `json:"name"`
+	Description        string        `json:"description,omitempty"`
+	Active             bool          `json:"active"`
+	Price              int           `json:"price"`
+	SortID             int           `json:"sort_id"`
+	OptionGroups       []OptionGroup `json:"extras,omitempty"`
+}
+
+// Group of modifiers on an item, e.g. "Choose a size"
+type OptionGroup struct {
+	MerchantSuppliedID string   `json:"merchant_supplied_id"`
+	Name               string   `json:"name"`
+	MinNumOptions      int      `json:"min_num_options"`
+	MaxNumOptions      int      `json:"max_num_options"`
+	NumFreeOptions     int      `json:"num_free_options"`
+	SortID             int      `json:"sort_id"`
+	Options            []Option `json:"options"`
+}
+
+// Modifier within an option group. Options can have nested option groups,
+// e.g. a choice of sauce for a side.
+type Option struct {
+	MerchantSuppliedID string        `json:"merchant_supplied_id"`
+	Name               string        `json:"name"`
+	Active             bool          `json:"active"`
+	Price              int           `json:"price"`
+	Default            bool          `json:"default"`
+	SortID             int           `json:"sort_id"`
+	OptionGroups       []OptionGroup `json:"extras,omitempty"`
+}
+
+// Asynchronous menu processing job started by a menu push. The outcome is
+// delivered to the menu job webhook.
+type MenuJob struct {
+	JobID  string `json:"job_id"`
+	MenuID string `json:"menu_id"`
+	Status string `json:"status"`
+}
+
+// Availability of a single item or option
+type ItemAvailability struct {
+	MerchantSuppliedID string `json:"merchant_supplied_id"`
+	Active             bool   `json:"is_active"`
+}
+
+// PushMenu validates a new menu and uploads it
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/CreateMenu
+func (c *Client) PushMenu(ctx context.Context, m *Menu, opts ...doordash.CallOption) (*MenuJob, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	res := &MenuJob{}
+	if err := c.makeRequest(ctx, "PushMenu", "POST", "marketplace/api/v1/menus", m, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateMenu validates a menu and replaces the existing menu with it
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/UpdateMenu
+func (c *Client) UpdateMenu(ctx context.Context, menuID string, m *Menu, opts ...doordash.CallOption) (*MenuJob, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	res := &MenuJob{}
+	if err := c.makeRequest(ctx, "UpdateMenu", "PATCH", ("marketplace/api/v1/menus/" + menuID), m, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateItemAvailability activates or deactivates items and options, e.g.
+// when an ingredient runs out, without pushing the whole menu
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/UpdateItemStatus
+func (c *Client) UpdateItemAvailability(ctx context.Context, storeID string, items []ItemAvailability, opts ...doordash.CallOption) error {
+	return c.makeRequest(ctx, "UpdateItemAvailability", "PATCH", ("marketplace/api/v1/stores/" + storeID + "/items/status"), items, nil, opts)
+}
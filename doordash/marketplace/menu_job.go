@@ -0,0 +1,48 @@
+// API Doc: https://developer.doordash.com/en-US/docs/marketplace/how_to/menu_webhooks
+package marketplace
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/alext251/doordash-go-sdk/doordash/internal/webhook"
+)
+
+const (
+	MenuJobSuccess = "SUCCESS"
+	MenuJobFailed  = "FAILED"
+)
+
+// Outcome of a menu job, sent by DoorDash once a pushed menu is processed
+type MenuJobEvent struct {
+	JobID   string `json:"job_id"`
+	MenuID  string `json:"menu_id"`
+	StoreID string `json:"store_id"`
+	// Reference given with the pushed menu
+	Reference string   `json:"reference"`
+	Status    string   `json:"status"`
+	Errors    []string `json:"errors"`
+}
+
+// ParseMenuJobEvent decodes a menu job webhook payload
+func ParseMenuJobEvent(r io.Reader) (*MenuJobEvent, error) {
+	e := &MenuJobEvent{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewMenuJobHandler returns an http.Handler that decodes menu job webhooks
+// and passes them to fn. Malformed payloads are answered with 400 and errors
+// returned by fn with 500 so DoorDash retries the delivery of the event.
+func NewMenuJobHandler(fn func(*MenuJobEvent) error) http.Handler {
+	return webhook.Handler(func(body io.Reader) error {
+		e, err := ParseMenuJobEvent(body)
+		if err != nil {
+			return webhook.Malformed(err)
+		}
+		return fn(e)
+	})
+}
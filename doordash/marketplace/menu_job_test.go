@@ -0,0 +1,46 @@
+package marketplace
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMenuJobHandler(t *testing.T) {
+	var received *MenuJobEvent
+	fail := false
+	handler := NewMenuJobHandler(func(e *MenuJobEvent) error {
+		received = e
+		if fail {
+			return errors.New("storage unavailable")
+		}
+		return nil
+	})
+
+	payload := `{"job_id": "J-1", "menu_id": "M-1", "store_id": "S-1", "reference": "menu-v1", "status": "FAILED", "errors": ["item I-1 has no price"]}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/menu-jobs", strings.NewReader(payload)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	if received == nil || received.Status != MenuJobFailed || received.Reference != "menu-v1" || len(received.Errors) != 1 {
+		t.Errorf("unexpected event %+v", received)
+	}
+
+	fail = true
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/menu-jobs", strings.NewReader(payload)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+
+	for method, body := range map[string]string{"GET": "", "POST": "{"} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/menu-jobs", strings.NewReader(body)))
+		if rec.Code == http.StatusOK {
+			t.Errorf("%s %q: expected request to be refused", method, body)
+		}
+	}
+}
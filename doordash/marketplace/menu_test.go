@@ -0,0 +1,104 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// newTestClient returns a Marketplace client sending its requests to handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	// Close the server when test finishes
+	t.Cleanup(server.Close)
+
+	url, _ := url.Parse(server.URL + "/")
+	c := doordash.NewClient("token", doordash.WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	return New(c)
+}
+
+// testMenu returns a small valid menu
+func testMenu() *Menu {
+	return &Menu{
+		Reference: "menu-v1",
+		Store:     MenuStore{MerchantSuppliedID: "S-1"},
+		OpenHours: []MenuHours{{DayIndex: "MON", StartTime: "08:00", EndTime: "22:00"}},
+		Menu: MenuContent{
+			Name:   "All day",
+			Active: true,
+			Categories: []Category{{
+				MerchantSuppliedID: "C-1",
+				Name:               "Burgers",
+				Active:             true,
+				Items: []Item{{
+					MerchantSuppliedID: "I-1",
+					Name:               "Cheeseburger",
+					Active:             true,
+					Price:              899,
+					OptionGroups: []OptionGroup{{
+						MerchantSuppliedID: "G-1",
+						Name:               "Side",
+						MinNumOptions:      1,
+						MaxNumOptions:      1,
+						Options: []Option{
+							{MerchantSuppliedID: "O-1", Name: "Fries", Active: true, Default: true},
+							{MerchantSuppliedID: "O-2", Name: "Salad", Active: true, Price: 100},
+						},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestPushMenu(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		json.NewDecoder(req.Body).Decode(&body)
+		// Send response to be tested
+		rw.WriteHeader(http.StatusAccepted)
+		rw.Write([]byte(`{"job_id": "J-1", "menu_id": "M-1", "status": "QUEUED"}`))
+	})
+	ctx := context.Background()
+
+	job, err := c.PushMenu(ctx, testMenu())
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if job.JobID != "J-1" || job.MenuID != "M-1" {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if body["reference"] != "menu-v1" {
+		t.Errorf("expected menu to be sent, got %v", body)
+	}
+
+	c.UpdateMenu(ctx, "M-1", testMenu())
+	c.UpdateItemAvailability(ctx, "S-1", []ItemAvailability{{MerchantSuppliedID: "I-1", Active: false}})
+	want := []string{
+		"POST /marketplace/api/v1/menus",
+		"PATCH /marketplace/api/v1/menus/M-1",
+		"PATCH /marketplace/api/v1/stores/S-1/items/status",
+	}
+	for i := range want {
+		if i >= len(requests) || requests[i] != want[i] {
+			t.Fatalf("expected requests %v, got %v", want, requests)
+		}
+	}
+
+	invalid := testMenu()
+	invalid.Menu.Categories = nil
+	if _, err := c.PushMenu(ctx, invalid); err == nil {
+		t.Error("expected invalid menu to be rejected, got nil")
+	}
+	if len(requests) != len(want) {
+		t.Errorf("expected invalid menu not to be sent, got requests %v", requests)
+	}
+}
@@ -0,0 +1,127 @@
+// Local validation of menus before upload
+package marketplace
+
+import (
+	"fmt"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var dayIndexes = map[string]bool{
+	"MON": true,
+	"TUE": true,
+	"WED": true,
+	"THU": true,
+	"FRI": true,
+	"SAT": true,
+	"SUN": true,
+}
+
+// Validate checks the menu's structure so mistakes are reported immediately
+// rather than by a failed menu job minutes later
+func (m *Menu) Validate() error {
+	if m.Store.MerchantSuppliedID == "" {
+		return fmt.Errorf("store: merchant_supplied_id is required")
+	}
+	if m.Menu.Name == "" {
+		return fmt.Errorf("menu: name is required")
+	}
+	if len(m.Menu.Categories) == 0 {
+		return fmt.Errorf("menu: at least one category is required")
+	}
+
+	for i, h := range m.OpenHours {
+		if !dayIndexes[h.DayIndex] {
+			return fmt.Errorf("open_hours[%d]: invalid day_index %q", i, h.DayIndex)
+		}
+		if err := doordash.ValidateTimeRange(h.StartTime, h.EndTime); err != nil {
+			return fmt.Errorf("open_hours[%d]: %v", i, err)
+		}
+	}
+	// Menus carry no timezone, so times are not checked against daylight
+	// saving changes
+	if err := doordash.ValidateSpecialHours(m.SpecialHours, nil); err != nil {
+		return err
+	}
+
+	// Merchant IDs identify items in availability updates and orders, so
+	// they must be unique across the menu. Options are only identified within
+	// their group, and a shared modifier may appear under several items.
+	items := map[string]bool{}
+	for i, cat := range m.Menu.Categories {
+		path := fmt.Sprintf("categories[%d]", i)
+		if cat.Name == "" {
+			return fmt.Errorf("%s: name is required", path)
+		}
+		if len(cat.Items) == 0 {
+			return fmt.Errorf("%s: at least one item is required", path)
+		}
+		for j, item := range cat.Items {
+			path := fmt.Sprintf("%s.items[%d]", path, j)
+			if err := validateEntry(path, item.MerchantSuppliedID, item.Name, item.Price, items); err != nil {
+				return err
+			}
+			if err := validateOptionGroups(path, item.OptionGroups); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateOptionGroups(path string, groups []OptionGroup) error {
+	for i, g := range groups {
+		path := fmt.Sprintf("%s.extras[%d]", path, i)
+		if g.Name == "" {
+			return fmt.Errorf("%s: name is required", path)
+		}
+		if len(g.Options) == 0 {
+			return fmt.Errorf("%s: at least one option is required", path)
+		}
+		if g.MinNumOptions < 0 || g.MinNumOptions > g.MaxNumOptions {
+			return fmt.Errorf("%s: min_num_options %d must be between 0 and max_num_options %d", path, g.MinNumOptions, g.MaxNumOptions)
+		}
+		if g.MaxNumOptions > len(g.Options) {
+			return fmt.Errorf("%s: max_num_options %d exceeds the %d options", path, g.MaxNumOptions, len(g.Options))
+		}
+		if g.NumFreeOptions < 0 || g.NumFreeOptions > g.MaxNumOptions {
+			return fmt.Errorf("%s: num_free_options %d must be between 0 and max_num_options %d", path, g.NumFreeOptions, g.MaxNumOptions)
+		}
+
+		defaults := 0
+		seen := map[string]bool{}
+		for j, o := range g.Options {
+			path := fmt.Sprintf("%s.options[%d]", path, j)
+			if err := validateEntry(path, o.MerchantSuppliedID, o.Name, o.Price, seen); err != nil {
+				return err
+			}
+			if o.Default {
+				defaults++
+			}
+			if err := validateOptionGroups(path, o.OptionGroups); err != nil {
+				return err
+			}
+		}
+		if defaults > g.MaxNumOptions {
+			return fmt.Errorf("%s: %d default options exceed max_num_options %d", path, defaults, g.MaxNumOptions)
+		}
+	}
+	return nil
+}
+
+func validateEntry(path string, id string, name string, price int, seen map[string]bool) error {
+	if id == "" {
+		return fmt.Errorf("%s: merchant_supplied_id is required", path)
+	}
+	if seen[id] {
+		return fmt.Errorf("%s: duplicate merchant_supplied_id %q", path, id)
+	}
+	seen[id] = true
+	if name == "" {
+		return fmt.Errorf("%s: name is required", path)
+	}
+	if price < 0 {
+		return fmt.Errorf("%s: price must not be negative", path)
+	}
+	return nil
+}
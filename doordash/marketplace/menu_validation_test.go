@@ -0,0 +1,60 @@
+package marketplace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestMenuValidate(t *testing.T) {
+	if err := testMenu().Validate(); err != nil {
+		t.Fatalf("expected valid menu, got %v", err)
+	}
+	overnight := testMenu()
+	overnight.OpenHours[0].EndTime = "02:00"
+	if err := overnight.Validate(); err != nil {
+		t.Errorf("expected hours past midnight to be valid, got %v", err)
+	}
+	shared := testMenu()
+	items := shared.Menu.Categories[0].Items
+	second := items[0]
+	second.MerchantSuppliedID, second.Name = "I-2", "Double cheeseburger"
+	shared.Menu.Categories[0].Items = append(items, second)
+	if err := shared.Validate(); err != nil {
+		t.Errorf("expected a modifier shared by two items to be valid, got %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		change func(m *Menu)
+		want   string
+	}{
+		"store": {func(m *Menu) { m.Store.MerchantSuppliedID = "" }, "store: merchant_supplied_id is required"},
+		"day":   {func(m *Menu) { m.OpenHours[0].DayIndex = "Monday" }, `open_hours[0]: invalid day_index "Monday"`},
+		"hours": {func(m *Menu) { m.OpenHours[0].EndTime = "08:00" }, `open_hours[0]: end_time "08:00" must differ from start_time "08:00"`},
+		"date":  {func(m *Menu) { m.SpecialHours = []doordash.SpecialHours{{Date: "12/25", Closed: true}} }, `special_hours[0]: invalid date "12/25"`},
+		"closed": {func(m *Menu) {
+			m.SpecialHours = []doordash.SpecialHours{{Date: "2022-12-25", Closed: true, StartTime: "10:00", EndTime: "14:00"}}
+		}, "special_hours[0]: closed dates cannot have start_time or end_time"},
+		"empty":     {func(m *Menu) { m.Menu.Categories[0].Items = nil }, "categories[0]: at least one item is required"},
+		"price":     {func(m *Menu) { m.Menu.Categories[0].Items[0].Price = -1 }, "categories[0].items[0]: price must not be negative"},
+		"max":       {func(m *Menu) { m.Menu.Categories[0].Items[0].OptionGroups[0].MaxNumOptions = 3 }, "categories[0].items[0].extras[0]: max_num_options 3 exceeds the 2 options"},
+		"min":       {func(m *Menu) { m.Menu.Categories[0].Items[0].OptionGroups[0].MinNumOptions = 2 }, "min_num_options 2 must be between 0 and max_num_options 1"},
+		"defaults":  {func(m *Menu) { m.Menu.Categories[0].Items[0].OptionGroups[0].Options[1].Default = true }, "2 default options exceed max_num_options 1"},
+		"duplicate": {func(m *Menu) { m.Menu.Categories[0].Items[0].OptionGroups[0].Options[1].MerchantSuppliedID = "O-1" }, `options[1]: duplicate merchant_supplied_id "O-1"`},
+		"item": {func(m *Menu) {
+			m.Menu.Categories[0].Items = append(m.Menu.Categories[0].Items, m.Menu.Categories[0].Items[0])
+		}, `items[1]: duplicate merchant_supplied_id "I-1"`},
+		"nested": {func(m *Menu) {
+			m.Menu.Categories[0].Items[0].OptionGroups[0].Options[0].OptionGroups = []OptionGroup{{Name: "Sauce"}}
+		}, "options[0].extras[0]: at least one option is required"},
+		"categories": {func(m *Menu) { m.Menu.Categories = nil }, "menu: at least one category is required"},
+	} {
+		m := testMenu()
+		tc.change(m)
+		err := m.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.want, err)
+		}
+	}
+}
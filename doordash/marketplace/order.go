@@ -0,0 +1,78 @@
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#tag/Order
+package marketplace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	orderStatusSuccess = "success"
+	orderStatusFail    = "fail"
+)
+
+// Marketplace order as sent in order webhooks. Prices are in cents.
+type Order struct {
+	ID                  string        `json:"id"`
+	Store               MenuStore     `json:"store"`
+	Customer            OrderCustomer `json:"consumer"`
+	Items               []OrderItem   `json:"items"`
+	Subtotal            int           `json:"subtotal"`
+	Tax                 int           `json:"tax"`
+	IsPickup            bool          `json:"is_pickup"`
+	Instructions        string        `json:"special_instructions"`
+	EstimatedPickupTime time.Time     `json:"estimated_pickup_time"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
+type OrderCustomer struct {
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	PhoneNumber string `json:"phone"`
+}
+
+// Ordered item, referring to the menu by merchant ID
+type OrderItem struct {
+	MerchantSuppliedID  string        `json:"merchant_supplied_id"`
+	Name                string        `json:"name"`
+	Quantity            int           `json:"quantity"`
+	Price               int           `json:"price"`
+	SpecialInstructions string        `json:"special_instructions"`
+	Options             []OrderOption `json:"options"`
+}
+
+// Chosen modifier, possibly with its own nested choices
+type OrderOption struct {
+	MerchantSuppliedID string        `json:"merchant_supplied_id"`
+	Name               string        `json:"name"`
+	Quantity           int           `json:"quantity"`
+	Price              int           `json:"price"`
+	Options            []OrderOption `json:"options"`
+}
+
+type orderConfirmation struct {
+	OrderStatus   string     `json:"order_status"`
+	PrepTime      *time.Time `json:"prep_time,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+}
+
+// ConfirmOrder accepts an order, to be ready prepTime from now, or rejects
+// it with a reason
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/ConfirmOrder
+func (c *Client) ConfirmOrder(ctx context.Context, orderID string, accept bool, prepTime time.Duration, reason string, opts ...doordash.CallOption) error {
+	body := &orderConfirmation{OrderStatus: orderStatusSuccess}
+	if accept {
+		ready := time.Now().Add(prepTime).UTC()
+		body.PrepTime = &ready
+	} else {
+		if reason == "" {
+			return fmt.Errorf("a reason is required to reject order %s", orderID)
+		}
+		body.OrderStatus, body.FailureReason = orderStatusFail, reason
+	}
+	return c.makeRequest(ctx, "ConfirmOrder", "PATCH", ("marketplace/api/v1/orders/" + orderID), body, nil, opts)
+}
@@ -0,0 +1,48 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConfirmOrder(t *testing.T) {
+	var paths []string
+	var bodies []map[string]interface{}
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		bodies = append(bodies, body)
+		// Send response to be tested
+		rw.WriteHeader(http.StatusAccepted)
+	})
+	ctx := context.Background()
+
+	if err := c.ConfirmOrder(ctx, "O-1", true, 15*time.Minute, ""); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if paths[0] != "PATCH /marketplace/api/v1/orders/O-1" || bodies[0]["order_status"] != "success" {
+		t.Errorf("unexpected confirmation %s %v", paths[0], bodies[0])
+	}
+	ready, err := time.Parse(time.RFC3339, bodies[0]["prep_time"].(string))
+	if err != nil || ready.Before(time.Now().Add(14*time.Minute)) {
+		t.Errorf("expected prep_time about 15 minutes from now, got %v", bodies[0]["prep_time"])
+	}
+
+	if err := c.ConfirmOrder(ctx, "O-2", false, 0, "item_unavailable"); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if bodies[1]["order_status"] != "fail" || bodies[1]["failure_reason"] != "item_unavailable" || bodies[1]["prep_time"] != nil {
+		t.Errorf("unexpected rejection %v", bodies[1])
+	}
+
+	if err := c.ConfirmOrder(ctx, "O-3", false, 0, ""); err == nil {
+		t.Error("expected rejection without reason to fail, got nil")
+	}
+	if len(paths) != 2 {
+		t.Errorf("expected rejection without reason not to be sent, got %v", paths)
+	}
+}
@@ -0,0 +1,193 @@
+// API Doc: https://developer.doordash.com/en-US/docs/marketplace/how_to/order_webhooks
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash/internal/webhook"
+)
+
+const (
+	// Time allowed for deciding on an order before it is rejected, well
+	// inside the deadline DoorDash gives merchants to confirm
+	DefaultDecisionTimeout = 2 * time.Minute
+	// Reason sent when an order is rejected automatically
+	DefaultRejectReason = "store_unavailable"
+	// Time allowed for sending a confirmation
+	confirmTimeout = 30 * time.Second
+	// Time an order ID is remembered to ignore redelivered webhooks
+	seenRetention = 24 * time.Hour
+)
+
+// Event type of the webhook sent for a newly placed order
+const OrderCreateEvent = "OrderCreate"
+
+// Order webhook as sent by DoorDash when a customer places an order
+type OrderEvent struct {
+	Event struct {
+		Type string `json:"type"`
+	} `json:"event"`
+	Order Order `json:"order"`
+}
+
+// Merchant's answer to an order
+type OrderDecision struct {
+	Accept bool
+	// Time needed to prepare the order when accepting it
+	PrepTime time.Duration
+	// Reason for rejecting the order
+	Reason string
+}
+
+// ParseOrderEvent decodes an order webhook payload
+func ParseOrderEvent(r io.Reader) (*OrderEvent, error) {
+	e := &OrderEvent{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// OrderHandler is an http.Handler receiving order webhooks. Each new order is
+// acknowledged immediately and passed to Decide in the background; the
+// decision is then sent with ConfirmOrder. If Decide fails or does not
+// return within Timeout, the order is rejected so it does not sit
+// unconfirmed until DoorDash cancels it. Other event types are acknowledged
+// without action, and an order delivered again within a day is only decided
+// once, unless its confirmation failed so the redelivery is the only retry.
+type OrderHandler struct {
+	Client *Client
+	Decide func(ctx context.Context, o *Order) (*OrderDecision, error)
+	// Defaults to DefaultDecisionTimeout
+	Timeout time.Duration
+	// Defaults to DefaultRejectReason
+	RejectReason string
+	// Called with errors from Decide and from confirming orders
+	OnError func(o *Order, err error)
+
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func (h *OrderHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	webhook.Handler(h.receive).ServeHTTP(rw, req)
+}
+
+func (h *OrderHandler) receive(body io.Reader) error {
+	e, err := ParseOrderEvent(body)
+	if err != nil {
+		return webhook.Malformed(err)
+	}
+	if e.Event.Type != OrderCreateEvent || !h.firstSeen(e.Order.ID) {
+		return nil
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.handle(&e.Order)
+	}()
+	return nil
+}
+
+// firstSeen records the order ID and reports whether it was new
+func (h *OrderHandler) firstSeen(orderID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if h.seen == nil {
+		h.seen = map[string]time.Time{}
+	}
+	if now.Sub(h.lastSweep) >= seenRetention {
+		for id, at := range h.seen {
+			if now.Sub(at) > seenRetention {
+				delete(h.seen, id)
+			}
+		}
+		h.lastSweep = now
+	}
+	if _, ok := h.seen[orderID]; ok {
+		return false
+	}
+	h.seen[orderID] = now
+	return true
+}
+
+// Wait blocks until every received order has been confirmed or rejected
+func (h *OrderHandler) Wait() {
+	h.wg.Wait()
+}
+
+func (h *OrderHandler) handle(o *Order) {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDecisionTimeout
+	}
+	decision := h.decide(o, timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), confirmTimeout)
+	defer cancel()
+	if err := h.Client.ConfirmOrder(ctx, o.ID, decision.Accept, decision.PrepTime, decision.Reason); err != nil {
+		h.forget(o.ID)
+		h.reportError(o, err)
+	}
+}
+
+// forget removes the order ID so a redelivered webhook is handled again
+func (h *OrderHandler) forget(orderID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, orderID)
+}
+
+// decide runs Decide within timeout, falling back to rejecting the order
+func (h *OrderHandler) decide(o *Order, timeout time.Duration) *OrderDecision {
+	reject := &OrderDecision{Reason: h.RejectReason}
+	if reject.Reason == "" {
+		reject.Reason = DefaultRejectReason
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		decision *OrderDecision
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		d, err := h.Decide(ctx, o)
+		done <- result{d, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			h.reportError(o, r.err)
+			return reject
+		}
+		if r.decision == nil {
+			return reject
+		}
+		if !r.decision.Accept && r.decision.Reason == "" {
+			r.decision.Reason = reject.Reason
+		}
+		return r.decision
+	case <-ctx.Done():
+		h.reportError(o, ctx.Err())
+		return reject
+	}
+}
+
+func (h *OrderHandler) reportError(o *Order, err error) {
+	if h.OnError != nil {
+		h.OnError(o, err)
+	}
+}
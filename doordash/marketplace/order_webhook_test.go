@@ -0,0 +1,149 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const orderPayload = `{
+	"event": {"type": "OrderCreate"},
+	"order": {
+		"id": "%s",
+		"store": {"merchant_supplied_id": "S-1"},
+		"consumer": {"first_name": "John", "last_name": "Doe", "phone": "+16505555555"},
+		"items": [{"merchant_supplied_id": "I-1", "name": "Cheeseburger", "quantity": 2, "price": 899,
+			"options": [{"merchant_supplied_id": "O-1", "name": "Fries", "quantity": 1, "price": 0}]}],
+		"subtotal": 1798,
+		"tax": 160
+	}
+}`
+
+func TestOrderHandler(t *testing.T) {
+	// Stand-in for DoorDash receiving confirmations
+	var mu sync.Mutex
+	confirmations := map[string]map[string]interface{}{}
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		mu.Lock()
+		confirmations[strings.TrimPrefix(req.URL.Path, "/marketplace/api/v1/orders/")] = body
+		mu.Unlock()
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	var errs []error
+	var decided int32
+	h := &OrderHandler{
+		Client:  c,
+		Timeout: 50 * time.Millisecond,
+		Decide: func(ctx context.Context, o *Order) (*OrderDecision, error) {
+			atomic.AddInt32(&decided, 1)
+			switch o.ID {
+			case "O-accept":
+				if len(o.Items) != 1 || o.Items[0].Options[0].Name != "Fries" || o.Customer.FirstName != "John" {
+					t.Errorf("unexpected order %+v", o)
+				}
+				return &OrderDecision{Accept: true, PrepTime: 20 * time.Minute}, nil
+			case "O-reject":
+				return &OrderDecision{}, nil
+			default:
+				// Kitchen display never answers
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+		},
+		OnError: func(o *Order, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	}
+
+	// O-accept is delivered twice, and O-cancel only with another event type
+	for _, id := range []string{"O-accept", "O-reject", "O-slow", "O-accept"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/orders", strings.NewReader(strings.Replace(orderPayload, "%s", id, 1))))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", id, rec.Code)
+		}
+	}
+	cancel := strings.Replace(strings.Replace(orderPayload, "%s", "O-cancel", 1), "OrderCreate", "OrderCancel", 1)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/orders", strings.NewReader(cancel)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected other events to be acknowledged, got %d", rec.Code)
+	}
+	h.Wait()
+
+	if n := atomic.LoadInt32(&decided); n != 3 {
+		t.Errorf("expected each new order to be decided once, got %d decisions", n)
+	}
+	if _, ok := confirmations["O-cancel"]; ok {
+		t.Errorf("expected other events not to be confirmed, got %v", confirmations["O-cancel"])
+	}
+
+	if confirmations["O-accept"]["order_status"] != "success" {
+		t.Errorf("expected order to be accepted, got %v", confirmations["O-accept"])
+	}
+	for _, id := range []string{"O-reject", "O-slow"} {
+		if confirmations[id]["order_status"] != "fail" || confirmations[id]["failure_reason"] != DefaultRejectReason {
+			t.Errorf("%s: expected order to be rejected, got %v", id, confirmations[id])
+		}
+	}
+	if len(errs) != 1 || errs[0] != context.DeadlineExceeded {
+		t.Errorf("expected timeout to be reported, got %v", errs)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/orders", strings.NewReader("{")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed payload, got %d", rec.Code)
+	}
+}
+
+func TestOrderHandlerConfirmFailure(t *testing.T) {
+	var confirms int32
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		// The first confirmation fails, as during a DoorDash outage
+		if atomic.AddInt32(&confirms, 1) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	var errs []error
+	h := &OrderHandler{
+		Client: c,
+		Decide: func(ctx context.Context, o *Order) (*OrderDecision, error) {
+			return &OrderDecision{Accept: true, PrepTime: 20 * time.Minute}, nil
+		},
+		OnError: func(o *Order, err error) {
+			errs = append(errs, err)
+		},
+	}
+
+	// DoorDash redelivers the order after each failed confirmation
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/orders", strings.NewReader(strings.Replace(orderPayload, "%s", "O-1", 1))))
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+		h.Wait()
+	}
+
+	if n := atomic.LoadInt32(&confirms); n != 2 {
+		t.Errorf("expected the order to be confirmed again after a failure and then ignored, got %d confirmations", n)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected the failed confirmation to be reported, got %v", errs)
+	}
+}
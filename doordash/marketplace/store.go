@@ -0,0 +1,77 @@
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#tag/Store
+package marketplace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Object containing response information for Marketplace stores
+type StoreDetails struct {
+	ID                 string                  `json:"id"`
+	MerchantSuppliedID string                  `json:"merchant_supplied_id"`
+	Name               string                  `json:"name"`
+	Timezone           string                  `json:"timezone"`
+	IsActive           bool                    `json:"is_active"`
+	PauseReason        string                  `json:"pause_reason"`
+	PausedUntil        time.Time               `json:"paused_until"`
+	OpenHours          []MenuHours             `json:"open_hours"`
+	SpecialHours       []doordash.SpecialHours `json:"special_hours"`
+}
+
+type storeStatus struct {
+	IsActive bool       `json:"is_active"`
+	Reason   string     `json:"reason,omitempty"`
+	EndTime  *time.Time `json:"end_time,omitempty"`
+}
+
+type specialHoursUpdate struct {
+	SpecialHours []doordash.SpecialHours `json:"special_hours"`
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/GetStoreDetails
+func (c *Client) GetStoreDetails(ctx context.Context, storeID string, opts ...doordash.CallOption) (*StoreDetails, error) {
+	res := &StoreDetails{}
+	if err := c.makeRequest(ctx, "GetStoreDetails", "GET", ("marketplace/api/v1/stores/" + storeID + "/store_details"), nil, res, opts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PauseStore stops the store taking orders for duration, e.g. when the
+// kitchen is overwhelmed. DoorDash is told when the pause ends; use an
+// Unpauser to make sure the store is reactivated even if it is not.
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/UpdateStoreStatus
+func (c *Client) PauseStore(ctx context.Context, storeID string, reason string, duration time.Duration, opts ...doordash.CallOption) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to pause store %s", storeID)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("pause duration for store %s must be positive", storeID)
+	}
+	end := time.Now().Add(duration).UTC()
+	body := &storeStatus{IsActive: false, Reason: reason, EndTime: &end}
+	return c.makeRequest(ctx, "PauseStore", "PUT", ("marketplace/api/v1/stores/" + storeID + "/status"), body, nil, opts)
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/UpdateStoreStatus
+func (c *Client) UnpauseStore(ctx context.Context, storeID string, opts ...doordash.CallOption) error {
+	body := &storeStatus{IsActive: true}
+	return c.makeRequest(ctx, "UnpauseStore", "PUT", ("marketplace/api/v1/stores/" + storeID + "/status"), body, nil, opts)
+}
+
+// SetSpecialHours validates and replaces the store's date overrides, e.g.
+// holiday closures. Dates and times are local to the store.
+//
+// API Spec: https://developer.doordash.com/en-US/api/marketplace#operation/UpdateStoreSpecialHours
+func (c *Client) SetSpecialHours(ctx context.Context, storeID string, hours []doordash.SpecialHours, opts ...doordash.CallOption) error {
+	if err := doordash.ValidateSpecialHours(hours, nil); err != nil {
+		return err
+	}
+	body := &specialHoursUpdate{SpecialHours: hours}
+	return c.makeRequest(ctx, "SetSpecialHours", "PUT", ("marketplace/api/v1/stores/" + storeID + "/special_hours"), body, nil, opts)
+}
@@ -0,0 +1,69 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestStoreStatus(t *testing.T) {
+	var paths []string
+	var bodies []map[string]interface{}
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		bodies = append(bodies, body)
+		// Send response to be tested
+		if req.Method == "GET" {
+			rw.Write([]byte(`{"id": "123", "merchant_supplied_id": "S-1", "name": "Burger Place", "is_active": false, "pause_reason": "busy", "paused_until": "2023-05-01T18:30:00Z", "special_hours": [{"date": "2023-12-25", "closed": true}]}`))
+		}
+	})
+	ctx := context.Background()
+
+	store, err := c.GetStoreDetails(ctx, "S-1")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if store.IsActive || store.PauseReason != "busy" || store.PausedUntil.IsZero() || !store.SpecialHours[0].Closed {
+		t.Errorf("unexpected store %+v", store)
+	}
+
+	if err := c.PauseStore(ctx, "S-1", "kitchen_busy", 30*time.Minute); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if bodies[1]["is_active"] != false || bodies[1]["reason"] != "kitchen_busy" || bodies[1]["end_time"] == nil {
+		t.Errorf("unexpected pause %v", bodies[1])
+	}
+	c.UnpauseStore(ctx, "S-1")
+	if bodies[2]["is_active"] != true || bodies[2]["end_time"] != nil {
+		t.Errorf("unexpected unpause %v", bodies[2])
+	}
+	c.SetSpecialHours(ctx, "S-1", []doordash.SpecialHours{{Date: "2023-12-24", StartTime: "10:00", EndTime: "15:00"}, {Date: "2023-12-25", Closed: true}})
+
+	want := []string{
+		"GET /marketplace/api/v1/stores/S-1/store_details",
+		"PUT /marketplace/api/v1/stores/S-1/status",
+		"PUT /marketplace/api/v1/stores/S-1/status",
+		"PUT /marketplace/api/v1/stores/S-1/special_hours",
+	}
+	for i := range want {
+		if i >= len(paths) || paths[i] != want[i] {
+			t.Fatalf("expected requests %v, got %v", want, paths)
+		}
+	}
+
+	if err := c.PauseStore(ctx, "S-1", "", time.Hour); err == nil {
+		t.Error("expected pause without reason to fail, got nil")
+	}
+	if err := c.SetSpecialHours(ctx, "S-1", []doordash.SpecialHours{{Date: "2023-12-25", Closed: true}, {Date: "2023-12-25", Closed: true}}); err == nil {
+		t.Error("expected duplicate dates to fail, got nil")
+	}
+	if len(paths) != len(want) {
+		t.Errorf("expected invalid requests not to be sent, got %v", paths)
+	}
+}
@@ -0,0 +1,160 @@
+// Automatic unpausing of Marketplace stores
+package marketplace
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultUnpauseInterval = 30 * time.Second
+
+// Unpauser pauses stores and unpauses them again once their pause is over,
+// so a store is not left closed if DoorDash does not end the pause itself.
+// Pending unpauses are kept in memory only. Pausing and unpausing the same
+// store never overlap, so an unpause that is due cannot end a newer pause.
+type Unpauser struct {
+	Client *Client
+	// How often Run checks for stores due to be unpaused, defaults to 30
+	// seconds
+	PollInterval time.Duration
+	// Called when unpausing a store fails; it is retried on the next pass
+	OnError func(storeID string, err error)
+	// Clock, defaults to time.Now
+	Now func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+	// Held while a store is being paused or unpaused
+	stores map[string]*sync.Mutex
+}
+
+// Pause pauses the store and schedules it to be unpaused after duration.
+// Pausing an already paused store cancels its scheduled unpause and replaces
+// it; if the pause fails, the earlier unpause is kept.
+func (u *Unpauser) Pause(ctx context.Context, storeID string, reason string, duration time.Duration) error {
+	lock := u.storeLock(storeID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	u.mu.Lock()
+	prev, scheduled := u.pending[storeID]
+	delete(u.pending, storeID)
+	u.mu.Unlock()
+
+	if err := u.Client.PauseStore(ctx, storeID, reason, duration); err != nil {
+		if scheduled {
+			u.Schedule(storeID, prev)
+		}
+		return err
+	}
+	u.Schedule(storeID, u.now().Add(duration))
+	return nil
+}
+
+// Schedule unpauses a store paused by other means at the given time
+func (u *Unpauser) Schedule(storeID string, at time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.pending == nil {
+		u.pending = map[string]time.Time{}
+	}
+	u.pending[storeID] = at
+}
+
+// Cancel forgets a scheduled unpause, e.g. after unpausing manually
+func (u *Unpauser) Cancel(storeID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.pending, storeID)
+}
+
+// Pending returns the scheduled unpause time of each paused store
+func (u *Unpauser) Pending() map[string]time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	pending := make(map[string]time.Time, len(u.pending))
+	for id, at := range u.pending {
+		pending[id] = at
+	}
+	return pending
+}
+
+// Run unpauses due stores every PollInterval until ctx is cancelled
+func (u *Unpauser) Run(ctx context.Context) error {
+	interval := u.PollInterval
+	if interval <= 0 {
+		interval = defaultUnpauseInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		u.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick unpauses every store whose pause is over
+func (u *Unpauser) Tick(ctx context.Context) {
+	now := u.now()
+	for id, at := range u.Pending() {
+		if now.Before(at) {
+			continue
+		}
+		u.unpause(ctx, id, at)
+	}
+}
+
+// unpause unpauses the store unless its unpause was cancelled or replaced
+// since it was found due
+func (u *Unpauser) unpause(ctx context.Context, storeID string, at time.Time) {
+	lock := u.storeLock(storeID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	u.mu.Lock()
+	current, ok := u.pending[storeID]
+	u.mu.Unlock()
+	if !ok || !current.Equal(at) {
+		return
+	}
+
+	if err := u.Client.UnpauseStore(ctx, storeID); err != nil {
+		if u.OnError != nil {
+			u.OnError(storeID, err)
+		}
+		return
+	}
+	u.mu.Lock()
+	// Schedule may have replaced the unpause while it was being sent
+	if u.pending[storeID].Equal(at) {
+		delete(u.pending, storeID)
+	}
+	u.mu.Unlock()
+}
+
+func (u *Unpauser) storeLock(storeID string) *sync.Mutex {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.stores == nil {
+		u.stores = map[string]*sync.Mutex{}
+	}
+	lock, ok := u.stores[storeID]
+	if !ok {
+		lock = &sync.Mutex{}
+		u.stores[storeID] = lock
+	}
+	return lock
+}
+
+func (u *Unpauser) now() time.Time {
+	if u.Now != nil {
+		return u.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,135 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestUnpauser(t *testing.T) {
+	var statuses []bool
+	fail := false
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		if fail {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body := struct {
+			IsActive bool `json:"is_active"`
+		}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		statuses = append(statuses, body.IsActive)
+	})
+
+	now := time.Date(2023, 5, 1, 18, 0, 0, 0, time.UTC)
+	var errs []error
+	u := &Unpauser{
+		Client:  c,
+		Now:     func() time.Time { return now },
+		OnError: func(storeID string, err error) { errs = append(errs, err) },
+	}
+	ctx := context.Background()
+
+	if err := u.Pause(ctx, "S-1", "kitchen_busy", 30*time.Minute); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	u.Schedule("S-2", now.Add(time.Hour))
+
+	now = now.Add(29 * time.Minute)
+	u.Tick(ctx)
+	if len(statuses) != 1 {
+		t.Errorf("expected no unpause before the pause is over, got %v", statuses)
+	}
+
+	now = now.Add(time.Minute)
+	fail = true
+	u.Tick(ctx)
+	var apiErr *doordash.Error
+	if len(errs) != 1 || !errors.As(errs[0], &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected failed unpause to be reported, got %v", errs)
+	}
+	if _, ok := u.Pending()["S-1"]; !ok {
+		t.Error("expected failed unpause to be retried")
+	}
+
+	fail = false
+	u.Tick(ctx)
+	if len(statuses) != 2 || !statuses[1] {
+		t.Errorf("expected store to be unpaused, got %v", statuses)
+	}
+	pending := u.Pending()
+	if _, ok := pending["S-1"]; ok || len(pending) != 1 {
+		t.Errorf("expected only S-2 to be pending, got %v", pending)
+	}
+
+	u.Cancel("S-2")
+	if len(u.Pending()) != 0 {
+		t.Errorf("expected cancelled unpause to be forgotten, got %v", u.Pending())
+	}
+}
+
+func TestUnpauserRepause(t *testing.T) {
+	var mu sync.Mutex
+	var statuses []bool
+	unpausing := make(chan struct{})
+	release := make(chan struct{})
+	c := newTestClient(t, func(rw http.ResponseWriter, req *http.Request) {
+		body := struct {
+			IsActive bool `json:"is_active"`
+		}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if body.IsActive {
+			close(unpausing)
+			<-release
+		}
+		mu.Lock()
+		statuses = append(statuses, body.IsActive)
+		mu.Unlock()
+	})
+
+	now := time.Date(2023, 5, 1, 18, 0, 0, 0, time.UTC)
+	u := &Unpauser{Client: c, Now: func() time.Time { return now }}
+	ctx := context.Background()
+	u.Schedule("S-1", now)
+
+	// The store is paused again while its due unpause is being sent
+	ticked := make(chan struct{})
+	go func() {
+		u.Tick(ctx)
+		close(ticked)
+	}()
+	<-unpausing
+	paused := make(chan error)
+	go func() {
+		paused <- u.Pause(ctx, "S-1", "kitchen_busy", time.Hour)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	<-ticked
+	if err := <-paused; err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if len(statuses) != 2 || !statuses[0] || statuses[1] {
+		t.Errorf("expected the pause to follow the unpause, got %v", statuses)
+	}
+	if at := u.Pending()["S-1"]; !at.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the new pause to be scheduled, got %v", u.Pending())
+	}
+
+	// A scheduled unpause does not end a newer pause
+	u.Schedule("S-2", now)
+	if err := u.Pause(ctx, "S-2", "kitchen_busy", time.Hour); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	u.Tick(ctx)
+	if len(statuses) != 3 || statuses[2] {
+		t.Errorf("expected no unpause of the paused store, got %v", statuses)
+	}
+}
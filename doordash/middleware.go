@@ -0,0 +1,51 @@
+// Middleware around API calls
+package doordash
+
+import "net/http"
+
+// A single API call as seen by middleware
+type Call struct {
+	// Name of the client method making the call, e.g. "CreateDelivery".
+	// Empty for calls sent with Do unless WithOperation is given.
+	Operation string
+	// Environment the client was marked with, for tagging logs and metrics
+	Environment Environment
+	Request     *http.Request
+	// Request body before encoding, e.g. *NewDelivery. Nil for calls without
+	// a body and for requests built with NewRequest.
+	Body interface{}
+	// Value the response is decoded into, e.g. *DeliveryInfo. It is filled
+	// in once the next RoundTripFunc returns without error.
+	Result interface{}
+	// Metadata and raw body of the HTTP response, set once the next
+	// RoundTripFunc returns. Middleware answering a call itself, e.g. from a
+	// cache, should set it and fill in Result.
+	Response *Response
+
+	// Times the call reached the client's transport
+	attempts int
+}
+
+// Sends a call and decodes its response
+type RoundTripFunc func(call *Call) error
+
+// Wraps the sending of every call made by a client, e.g. to add headers,
+// log, audit, cache or inject failures. Middleware may change call.Request
+// before calling next, or answer the call without calling next at all.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware to the client. The first middleware given
+// is the outermost, seeing each call first and its result last.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithOperation names a call sent with Do so middleware can tell it apart,
+// e.g. from packages building their own requests with NewRequest
+func WithOperation(name string) CallOption {
+	return func(cfg *callConfig) {
+		cfg.operation = name
+	}
+}
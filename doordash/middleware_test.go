@@ -0,0 +1,122 @@
+package doordash
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		header = req.Header.Get("X-Team")
+		// Send response to be tested
+		rw.Write(deliveryResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	var order []string
+	var audited *DeliveryInfo
+	tag := func(next RoundTripFunc) RoundTripFunc {
+		return func(call *Call) error {
+			order = append(order, "tag")
+			call.Request.Header.Set("X-Team", "logistics")
+			return next(call)
+		}
+	}
+	audit := func(next RoundTripFunc) RoundTripFunc {
+		return func(call *Call) error {
+			order = append(order, "audit:"+call.Operation)
+			if _, ok := call.Body.(*NewDelivery); call.Operation == "CreateDelivery" && !ok {
+				t.Errorf("expected typed request body, got %T", call.Body)
+			}
+			err := next(call)
+			audited, _ = call.Result.(*DeliveryInfo)
+			return err
+		}
+	}
+
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithMiddleware(tag, audit), WithHTTPClient(server.Client()))
+	c.BaseURL = url
+
+	d, err := c.CreateDelivery(&NewDelivery{ExternalDeliveryID: "D-12345"})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if header != "logistics" {
+		t.Errorf("expected header added by middleware, got %q", header)
+	}
+	if len(order) != 2 || order[0] != "tag" || order[1] != "audit:CreateDelivery" {
+		t.Errorf("unexpected middleware order %v", order)
+	}
+	if audited != d {
+		t.Errorf("expected middleware to see the decoded delivery")
+	}
+
+	req, _ := c.NewRequest("GET", "drive/v1/estimates", nil)
+	c.Do(req, nil, WithOperation("GetEstimate"))
+	if order[len(order)-1] != "audit:GetEstimate" {
+		t.Errorf("expected operation given to Do, got %v", order)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	chaos := errors.New("injected failure")
+	c := NewClient("token", WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(call *Call) error {
+			return chaos
+		}
+	}), WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Error("expected request not to be sent")
+		return nil, errors.New("unexpected request")
+	})}))
+
+	if _, err := c.GetDeliveryStatus("D-12345"); err != chaos {
+		t.Errorf("expected injected error, got %v", err)
+	}
+}
+
+func TestMiddlewareRetryAttempts(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		// Send response to be tested
+		if len(bodies) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write(deliveryResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	retry := func(next RoundTripFunc) RoundTripFunc {
+		return func(call *Call) error {
+			err := next(call)
+			if apiErr, ok := err.(*Error); ok && apiErr.StatusCode == http.StatusServiceUnavailable {
+				err = next(call)
+			}
+			return err
+		}
+	}
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithMiddleware(retry), WithHTTPClient(server.Client()))
+	c.BaseURL = url
+
+	var meta Response
+	if _, err := c.CreateDelivery(&NewDelivery{ExternalDeliveryID: "D-12345"}, WithResponseCapture(&meta)); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if meta.Attempts != 2 || meta.StatusCode != http.StatusOK {
+		t.Errorf("expected 2 attempts, got %d with status %d", meta.Attempts, meta.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("expected the body to be sent again on retry, got %q", bodies)
+	}
+}
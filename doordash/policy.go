@@ -0,0 +1,172 @@
+// Policies deciding whether a delivery quote should be accepted
+package doordash
+
+import (
+	"fmt"
+	"time"
+)
+
+// Information available to a QuotePolicy
+type QuoteContext struct {
+	// The quote request, used to compare the quote against what was asked for
+	Request *NewQuote
+	// The quote returned by CreateDeliveryQuote
+	Quote *DeliveryInfo
+	// Time of the evaluation
+	Now time.Time
+}
+
+// Outcome of evaluating a quote against a policy
+type QuoteDecision struct {
+	Accept bool `json:"accept"`
+	// Why the quote was rejected, one entry per failed rule
+	Reasons []QuoteReason `json:"reasons,omitempty"`
+}
+
+type QuoteReason struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// QuotePolicy decides whether a quote should be accepted. Custom rules can
+// implement it directly or use QuotePolicyFunc.
+type QuotePolicy interface {
+	Evaluate(qc *QuoteContext) *QuoteDecision
+}
+
+// Adapter to use an ordinary function as a QuotePolicy
+type QuotePolicyFunc func(qc *QuoteContext) *QuoteDecision
+
+func (f QuotePolicyFunc) Evaluate(qc *QuoteContext) *QuoteDecision {
+	return f(qc)
+}
+
+// AllOf accepts a quote only if every policy accepts it, collecting the
+// reasons of all policies that reject it
+func AllOf(policies ...QuotePolicy) QuotePolicy {
+	return QuotePolicyFunc(func(qc *QuoteContext) *QuoteDecision {
+		decision := &QuoteDecision{Accept: true}
+		for _, p := range policies {
+			d := p.Evaluate(qc)
+			if !d.Accept {
+				decision.Accept = false
+				decision.Reasons = append(decision.Reasons, d.Reasons...)
+			}
+		}
+		return decision
+	})
+}
+
+// MaxFee rejects quotes with a fee, in cents, above the ceiling of the
+// pickup store, or above Default for stores without their own ceiling. A
+// ceiling of zero means no limit.
+type MaxFee struct {
+	Default  int
+	PerStore map[string]int
+}
+
+func (p MaxFee) Evaluate(qc *QuoteContext) *QuoteDecision {
+	ceiling, ok := p.PerStore[qc.Quote.PickupExternalStoreID]
+	if !ok {
+		ceiling = p.Default
+	}
+	if ceiling > 0 && qc.Quote.Fee > ceiling {
+		return reject("max_fee", "fee %d exceeds ceiling of %d", qc.Quote.Fee, ceiling)
+	}
+	return accept()
+}
+
+// MaxFeeRatio rejects quotes whose fee exceeds a fraction of the order value,
+// e.g. 0.15 for 15%. The order value is the one sent in the request; the
+// value echoed in the quote is only used when the context has no request.
+type MaxFeeRatio float64
+
+func (p MaxFeeRatio) Evaluate(qc *QuoteContext) *QuoteDecision {
+	orderValue := qc.Quote.OrderValue
+	if qc.Request != nil {
+		orderValue = qc.Request.OrderValue
+	}
+	if orderValue <= 0 {
+		return reject("max_fee_ratio", "quote has no order value")
+	}
+	ratio := float64(qc.Quote.Fee) / float64(orderValue)
+	if ratio > float64(p) {
+		return reject("max_fee_ratio", "fee is %.1f%% of order value, maximum is %.1f%%", ratio*100, float64(p)*100)
+	}
+	return accept()
+}
+
+// MaxETA rejects quotes whose estimated dropoff is further away than the
+// given duration
+type MaxETA time.Duration
+
+func (p MaxETA) Evaluate(qc *QuoteContext) *QuoteDecision {
+	if qc.Quote.DropoffTimeEstimated.IsZero() {
+		return reject("max_eta", "quote has no dropoff estimate")
+	}
+	eta := qc.Quote.DropoffTimeEstimated.Sub(qc.Now)
+	if eta > time.Duration(p) {
+		return reject("max_eta", "estimated dropoff in %v, maximum is %v", eta.Round(time.Minute), time.Duration(p))
+	}
+	return accept()
+}
+
+// MaxDropoffDrift rejects quotes whose estimated dropoff falls further than
+// the given duration outside the requested dropoff window, or from the
+// requested dropoff time. Quotes for requests without either always pass.
+type MaxDropoffDrift time.Duration
+
+func (p MaxDropoffDrift) Evaluate(qc *QuoteContext) *QuoteDecision {
+	var start, end time.Time
+	switch {
+	case qc.Request == nil:
+		return accept()
+	case !qc.Request.DropoffWindow.StartTime.IsZero() || !qc.Request.DropoffWindow.EndTime.IsZero():
+		start, end = qc.Request.DropoffWindow.StartTime, qc.Request.DropoffWindow.EndTime
+	case !qc.Request.DropoffTime.IsZero():
+		start, end = qc.Request.DropoffTime, qc.Request.DropoffTime
+	default:
+		return accept()
+	}
+
+	estimate := qc.Quote.DropoffTimeEstimated
+	if estimate.IsZero() {
+		return reject("max_dropoff_drift", "quote has no dropoff estimate")
+	}
+
+	var drift time.Duration
+	switch {
+	case !start.IsZero() && estimate.Before(start):
+		drift = start.Sub(estimate)
+	case !end.IsZero() && estimate.After(end):
+		drift = estimate.Sub(end)
+	}
+	if drift > time.Duration(p) {
+		return reject("max_dropoff_drift", "estimated dropoff is %v outside the requested time, maximum is %v", drift.Round(time.Minute), time.Duration(p))
+	}
+	return accept()
+}
+
+// AcceptDeliveryQuoteWithPolicy evaluates a quote and accepts it only if the
+// policy allows it. When the policy rejects the quote, the returned delivery
+// and error are nil and the decision explains why.
+func (c *Client) AcceptDeliveryQuoteWithPolicy(req *NewQuote, quote *DeliveryInfo, p QuotePolicy) (*DeliveryInfo, *QuoteDecision, error) {
+	decision := p.Evaluate(&QuoteContext{Request: req, Quote: quote, Now: time.Now()})
+	if !decision.Accept {
+		return nil, decision, nil
+	}
+
+	res, err := c.AcceptDeliveryQuote(quote.ExternalDeliveryID)
+	if err != nil {
+		return nil, decision, err
+	}
+	return res, decision, nil
+}
+
+func accept() *QuoteDecision {
+	return &QuoteDecision{Accept: true}
+}
+
+func reject(rule string, format string, args ...interface{}) *QuoteDecision {
+	return &QuoteDecision{Reasons: []QuoteReason{{Rule: rule, Message: fmt.Sprintf(format, args...)}}}
+}
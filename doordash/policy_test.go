@@ -0,0 +1,125 @@
+package doordash
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuotePolicies(t *testing.T) {
+	now := time.Date(2018, 8, 22, 17, 0, 0, 0, time.UTC)
+	quote := &DeliveryInfo{
+		PickupExternalStoreID: "S-1",
+		Fee:                   975,
+		OrderValue:            5000,
+		DropoffTimeEstimated:  now.Add(45 * time.Minute),
+	}
+
+	tests := []struct {
+		name    string
+		policy  QuotePolicy
+		request *NewQuote
+		accept  bool
+	}{
+		{name: "fee under ceiling", policy: MaxFee{Default: 1000}, accept: true},
+		{name: "fee over ceiling", policy: MaxFee{Default: 900}, accept: false},
+		{name: "store ceiling", policy: MaxFee{Default: 1000, PerStore: map[string]int{"S-1": 500}}, accept: false},
+		{name: "no ceiling", policy: MaxFee{}, accept: true},
+		{name: "no store ceiling", policy: MaxFee{Default: 500, PerStore: map[string]int{"S-1": 0}}, accept: true},
+		{name: "ratio under", policy: MaxFeeRatio(0.2), request: &NewQuote{OrderValue: 5000}, accept: true},
+		{name: "ratio over", policy: MaxFeeRatio(0.15), request: &NewQuote{OrderValue: 5000}, accept: false},
+		{name: "ratio of requested order value", policy: MaxFeeRatio(0.2), request: &NewQuote{OrderValue: 2000}, accept: false},
+		{name: "ratio without requested order value", policy: MaxFeeRatio(0.2), request: &NewQuote{}, accept: false},
+		{name: "ratio of quoted order value", policy: MaxFeeRatio(0.2), accept: true},
+		{name: "eta under", policy: MaxETA(time.Hour), accept: true},
+		{name: "eta over", policy: MaxETA(30 * time.Minute), accept: false},
+		{name: "no requested dropoff", policy: MaxDropoffDrift(0), request: &NewQuote{}, accept: true},
+		{
+			name:    "inside window",
+			policy:  MaxDropoffDrift(0),
+			request: &NewQuote{DropoffWindow: TimeWindow{StartTime: now.Add(30 * time.Minute), EndTime: now.Add(time.Hour)}},
+			accept:  true,
+		},
+		{
+			name:    "after window",
+			policy:  MaxDropoffDrift(10 * time.Minute),
+			request: &NewQuote{DropoffWindow: TimeWindow{StartTime: now, EndTime: now.Add(30 * time.Minute)}},
+			accept:  false,
+		},
+		{
+			name:    "near dropoff time",
+			policy:  MaxDropoffDrift(10 * time.Minute),
+			request: &NewQuote{DropoffTime: now.Add(40 * time.Minute)},
+			accept:  true,
+		},
+		{
+			name: "custom rule",
+			policy: QuotePolicyFunc(func(qc *QuoteContext) *QuoteDecision {
+				return &QuoteDecision{Reasons: []QuoteReason{{Rule: "never", Message: "no deliveries today"}}}
+			}),
+			accept: false,
+		},
+	}
+
+	for _, tt := range tests {
+		d := tt.policy.Evaluate(&QuoteContext{Request: tt.request, Quote: quote, Now: now})
+		if d.Accept != tt.accept {
+			t.Errorf("%s: expected accept %v, got %v with %v", tt.name, tt.accept, d.Accept, d.Reasons)
+		}
+		if !d.Accept && len(d.Reasons) == 0 {
+			t.Errorf("%s: expected a reason for rejecting the quote", tt.name)
+		}
+	}
+}
+
+func TestAllOf(t *testing.T) {
+	quote := &DeliveryInfo{Fee: 975, OrderValue: 5000}
+	d := AllOf(MaxFee{Default: 900}, MaxFeeRatio(0.5), MaxFeeRatio(0.1)).Evaluate(&QuoteContext{Quote: quote})
+
+	var rules []string
+	for _, r := range d.Reasons {
+		rules = append(rules, r.Rule)
+	}
+	if d.Accept || !reflect.DeepEqual(rules, []string{"max_fee", "max_fee_ratio"}) {
+		t.Errorf("expected rejection by max_fee and max_fee_ratio, got %v", d)
+	}
+}
+
+func TestAcceptDeliveryQuoteWithPolicy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		// Test request parameters
+		reqURL := req.URL.String()
+		if reqURL != "/drive/v2/quotes/D-12345/accept" {
+			t.Errorf("expected request URL to be /drive/v2/quotes/D-12345/accept, got %s", reqURL)
+		}
+		// Send response to be tested
+		rw.Write(deliveryResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	quote := &DeliveryInfo{}
+	json.Unmarshal(deliveryResponse, quote)
+
+	url, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
+
+	got, decision, err := client.AcceptDeliveryQuoteWithPolicy(nil, quote, MaxFee{Default: 1000})
+	if err != nil || got != nil || decision.Accept || calls != 0 {
+		t.Errorf("expected rejection without accepting, got %v, %v, %v after %d calls", got, decision, err, calls)
+	}
+
+	got, decision, err = client.AcceptDeliveryQuoteWithPolicy(nil, quote, MaxFee{Default: 2000})
+	if err != nil || !decision.Accept || calls != 1 {
+		t.Fatalf("expected quote to be accepted, got %v, %v after %d calls", decision, err, calls)
+	}
+	if !reflect.DeepEqual(got, quote) {
+		t.Errorf("expected response to be %v, got %v", quote, got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package pool caches DoorDash clients for many tenants, each with their own
+// Drive credentials, on top of a single shared HTTP transport.
+package pool
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	defaultTimeout     = time.Minute
+	defaultIdleTimeout = 30 * time.Minute
+)
+
+type (
+	// TenantCredentials returns the credential provider of a tenant. It is
+	// called once when the tenant's client is created; the provider itself
+	// is asked on every request, so rotated keys are picked up without
+	// evicting the client.
+	TenantCredentials func(tenantID string) (doordash.CredentialProvider, error)
+
+	Options struct {
+		// Transport shared by every client, defaults to a clone of
+		// http.DefaultTransport
+		Transport http.RoundTripper
+		// Request timeout of every client, defaults to one minute
+		Timeout time.Duration
+		// Clients unused for this long are evicted, defaults to 30 minutes
+		IdleTimeout time.Duration
+		// Overrides the API base URL of every client
+		BaseURL *url.URL
+		// Additional options applied to every client
+		ClientOptions []doordash.ClientOption
+		// Clock, defaults to time.Now
+		Now func() time.Time
+	}
+
+	ClientPool struct {
+		credentials TenantCredentials
+		opts        Options
+		http        *http.Client
+
+		mu        sync.Mutex
+		clients   map[string]*entry
+		lastSweep time.Time
+	}
+
+	// A tenant's client, or its creation in progress until done is closed
+	entry struct {
+		done   chan struct{}
+		client *doordash.Client
+		err    error
+		usedAt time.Time
+	}
+)
+
+func NewClientPool(credentials TenantCredentials, opts Options) *ClientPool {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultIdleTimeout
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &ClientPool{
+		credentials: credentials,
+		opts:        opts,
+		http:        &http.Client{Transport: opts.Transport, Timeout: opts.Timeout},
+		clients:     map[string]*entry{},
+	}
+}
+
+// Client returns the tenant's client, creating it on first use. Credentials
+// are looked up without holding the pool's lock, so a slow lookup only
+// delays callers asking for the same tenant.
+func (p *ClientPool) Client(tenantID string) (*doordash.Client, error) {
+	p.mu.Lock()
+	now := p.opts.Now()
+	if now.Sub(p.lastSweep) >= p.opts.IdleTimeout {
+		p.evictIdle(now)
+		p.lastSweep = now
+	}
+	e, ok := p.clients[tenantID]
+	if ok {
+		e.usedAt = now
+		p.mu.Unlock()
+		<-e.done
+		return e.client, e.err
+	}
+	e = &entry{done: make(chan struct{}), usedAt: now}
+	p.clients[tenantID] = e
+	p.mu.Unlock()
+
+	e.client, e.err = p.newClient(tenantID)
+	if e.err != nil {
+		// Let the next call try again
+		p.mu.Lock()
+		if p.clients[tenantID] == e {
+			delete(p.clients, tenantID)
+		}
+		p.mu.Unlock()
+	}
+	close(e.done)
+	return e.client, e.err
+}
+
+// Evict drops the tenant's cached client, e.g. after its credentials are
+// revoked
+func (p *ClientPool) Evict(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, tenantID)
+}
+
+// EvictIdle drops clients unused for longer than the idle timeout and
+// returns how many were dropped. Client also does this periodically.
+func (p *ClientPool) EvictIdle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evictIdle(p.opts.Now())
+}
+
+// Len returns the number of cached clients
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+func (p *ClientPool) newClient(tenantID string) (*doordash.Client, error) {
+	creds, err := p.credentials(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]doordash.ClientOption{doordash.WithHTTPClient(p.http)}, p.opts.ClientOptions...)
+	client := doordash.NewClientWithCredentials(creds, opts...)
+	if p.opts.BaseURL != nil {
+		client.BaseURL = p.opts.BaseURL
+	}
+	return client, nil
+}
+
+func (p *ClientPool) evictIdle(now time.Time) int {
+	evicted := 0
+	for id, e := range p.clients {
+		if now.Sub(e.usedAt) > p.opts.IdleTimeout {
+			delete(p.clients, id)
+			evicted++
+		}
+	}
+	return evicted
+}
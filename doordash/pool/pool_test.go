@@ -0,0 +1,157 @@
+package pool
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// keyID returns the kid claim of a request's JWT
+func keyID(req *http.Request) string {
+	parts := strings.Split(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	i := strings.Index(string(claims), `"kid":"`)
+	if i < 0 {
+		return ""
+	}
+	rest := string(claims)[i+len(`"kid":"`):]
+	return rest[:strings.Index(rest, `"`)]
+}
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		keys = append(keys, keyID(req))
+		mu.Unlock()
+		// Send response to be tested
+		rw.Write([]byte(`{"external_business_id": "B-1"}`))
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	secret := base64.RawURLEncoding.EncodeToString([]byte("secret"))
+	creds := map[string]*doordash.Credentials{
+		"merchant-a": {DeveloperID: "dev", KeyID: "key-a", SigningSecret: secret},
+		"merchant-b": {DeveloperID: "dev", KeyID: "key-b", SigningSecret: secret},
+	}
+	calls := 0
+	lookup := func(tenantID string) (doordash.CredentialProvider, error) {
+		calls++
+		c, ok := creds[tenantID]
+		if !ok {
+			return nil, errors.New("unknown tenant")
+		}
+		// Read on every request, like a rotating secret store
+		return doordash.CredentialProviderFunc(func() (*doordash.Credentials, error) {
+			return c, nil
+		}), nil
+	}
+
+	now := time.Date(2022, 4, 25, 17, 0, 0, 0, time.UTC)
+	baseURL, _ := url.Parse(server.URL + "/")
+	p := NewClientPool(lookup, Options{
+		BaseURL:     baseURL,
+		IdleTimeout: time.Hour,
+		Now:         func() time.Time { return now },
+	})
+
+	a1, err := p.Client("merchant-a")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	a2, _ := p.Client("merchant-a")
+	if a1 != a2 || calls != 1 {
+		t.Errorf("expected cached client without looking up credentials again, got %d calls", calls)
+	}
+
+	b, _ := p.Client("merchant-b")
+	a1.GetBusiness("B-1")
+	b.GetBusiness("B-1")
+	if len(keys) != 2 || keys[0] != "key-a" || keys[1] != "key-b" {
+		t.Errorf("expected requests signed with each tenant's key, got %v", keys)
+	}
+
+	// Rotated key is used by the cached client
+	creds["merchant-a"].KeyID = "key-a2"
+	a1.GetBusiness("B-1")
+	if keys[2] != "key-a2" {
+		t.Errorf("expected rotated key to be used, got %v", keys)
+	}
+
+	if _, err := p.Client("merchant-c"); err == nil {
+		t.Error("expected error for an unknown tenant, got nil")
+	}
+	creds["merchant-c"] = &doordash.Credentials{DeveloperID: "dev", KeyID: "key-c", SigningSecret: secret}
+	if _, err := p.Client("merchant-c"); err != nil {
+		t.Errorf("expected failed lookups not to be cached, got %v", err)
+	}
+
+	// merchant-b and merchant-c have not been used for over an hour
+	now = now.Add(2 * time.Hour)
+	p.Client("merchant-a")
+	if p.Len() != 1 {
+		t.Errorf("expected idle clients to be evicted, got %d clients", p.Len())
+	}
+
+	p.Evict("merchant-a")
+	if p.Len() != 0 {
+		t.Errorf("expected no clients after eviction, got %d", p.Len())
+	}
+}
+
+func TestClientPoolSlowLookup(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	calls := map[string]int{}
+	lookup := func(tenantID string) (doordash.CredentialProvider, error) {
+		mu.Lock()
+		calls[tenantID]++
+		mu.Unlock()
+		if tenantID == "slow" {
+			<-release
+		}
+		return doordash.StaticCredentials(doordash.Credentials{DeveloperID: "dev", KeyID: tenantID, SigningSecret: "c2VjcmV0"}), nil
+	}
+	p := NewClientPool(lookup, Options{})
+
+	var wg sync.WaitGroup
+	clients := make([]*doordash.Client, 3)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = p.Client("slow")
+		}(i)
+	}
+
+	// Other tenants are not held up by the slow lookup
+	done := make(chan struct{})
+	go func() {
+		p.Client("fast")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected fast tenant not to wait for the slow lookup")
+	}
+
+	close(release)
+	wg.Wait()
+	if calls["slow"] != 1 || clients[0] == nil || clients[0] != clients[1] || clients[1] != clients[2] {
+		t.Errorf("expected one lookup shared by concurrent callers, got %d lookups", calls["slow"])
+	}
+}
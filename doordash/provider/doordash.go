@@ -0,0 +1,180 @@
+// DoorDash Drive adapter
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// DoorDash delivery statuses and the neutral statuses they map to
+var doordashStatuses = map[string]Status{
+	"quote":              StatusQuoted,
+	"created":            StatusCreated,
+	"confirmed":          StatusAssigned,
+	"enroute_to_pickup":  StatusAssigned,
+	"arrived_at_pickup":  StatusAssigned,
+	"picked_up":          StatusPickedUp,
+	"enroute_to_dropoff": StatusPickedUp,
+	"arrived_at_dropoff": StatusPickedUp,
+	"delivered":          StatusDelivered,
+	"cancelled":          StatusCancelled,
+	"enroute_to_return":  StatusReturned,
+	"returned":           StatusReturned,
+}
+
+// PATCH body of Update, holding only the fields being changed
+type doordashUpdate struct {
+	PickupInstructions  string `json:"pickup_instructions,omitempty"`
+	DropoffInstructions string `json:"dropoff_instructions,omitempty"`
+	DropoffPhoneNumber  string `json:"dropoff_phone_number,omitempty"`
+	Tip                 *int   `json:"tip,omitempty"`
+}
+
+type doordashProvider struct {
+	client *doordash.Client
+	// Business the StoreID of pickup locations belongs to
+	businessID string
+}
+
+// NewDoorDash returns a provider backed by the Drive API. Pickup locations with
+// a StoreID refer to stores of externalBusinessID.
+func NewDoorDash(c *doordash.Client, externalBusinessID string) DeliveryProvider {
+	return &doordashProvider{client: c, businessID: externalBusinessID}
+}
+
+func (p *doordashProvider) Name() string {
+	return "doordash"
+}
+
+func (p *doordashProvider) Quote(ctx context.Context, req *DeliveryRequest) (*Quote, error) {
+	q := doordash.NewQuote(*p.newDelivery(req))
+	res, err := p.client.CreateDeliveryQuote(&q, doordash.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{
+		ID:         res.ExternalDeliveryID,
+		Fee:        res.Fee,
+		Currency:   res.Currency,
+		PickupETA:  res.PickupTimeEstimated,
+		DropoffETA: res.DropoffTimeEstimated,
+	}, nil
+}
+
+func (p *doordashProvider) Create(ctx context.Context, req *DeliveryRequest) (*Delivery, error) {
+	return p.delivery(p.client.CreateDelivery(p.newDelivery(req), doordash.WithContext(ctx)))
+}
+
+func (p *doordashProvider) Get(ctx context.Context, id string) (*Delivery, error) {
+	return p.delivery(p.client.GetDeliveryStatus(id, doordash.WithContext(ctx)))
+}
+
+// Update sends only the fields set in u. doordash.DeliveryUpdate would send
+// every field and clear the ones left empty, so the request is built here.
+func (p *doordashProvider) Update(ctx context.Context, id string, u *DeliveryUpdate) (*Delivery, error) {
+	req, err := p.client.NewRequestWithContext(ctx, "PATCH", ("drive/v2/deliveries/" + id), &doordashUpdate{
+		PickupInstructions:  u.PickupInstructions,
+		DropoffInstructions: u.DropoffInstructions,
+		DropoffPhoneNumber:  u.DropoffPhoneNumber,
+		Tip:                 u.Tip,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &doordash.DeliveryInfo{}
+	if err := p.client.Do(req, res, doordash.WithOperation("UpdateDelivery")); err != nil {
+		return p.delivery(nil, err)
+	}
+	return p.toDelivery(res), nil
+}
+
+func (p *doordashProvider) Cancel(ctx context.Context, id string) (*Delivery, error) {
+	return p.delivery(p.client.CancelDelivery(id, doordash.WithContext(ctx)))
+}
+
+func (p *doordashProvider) ParseEvent(r io.Reader) (*Event, error) {
+	e, err := doordash.ParseDeliveryEvent(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		DeliveryID: e.ExternalDeliveryID,
+		Provider:   p.Name(),
+		Status:     doordashStatus(e.DeliveryStatus),
+		Type:       e.EventName,
+		At:         e.CreatedAt,
+		Delivery:   p.toDelivery(&e.DeliveryInfo),
+	}, nil
+}
+
+func (p *doordashProvider) newDelivery(req *DeliveryRequest) *doordash.NewDelivery {
+	given, family := splitName(req.Dropoff.ContactName)
+	d := &doordash.NewDelivery{
+		ExternalDeliveryID:       req.ID,
+		PickupAddress:            req.Pickup.Address,
+		PickupBusinessName:       req.Pickup.BusinessName,
+		PickupPhoneNumber:        req.Pickup.PhoneNumber,
+		PickupInstructions:       req.Pickup.Instructions,
+		DropoffAddress:           req.Dropoff.Address,
+		DropoffBusinessName:      req.Dropoff.BusinessName,
+		DropoffPhoneNumber:       req.Dropoff.PhoneNumber,
+		DropoffInstructions:      req.Dropoff.Instructions,
+		DropoffContactGivenName:  given,
+		DropoffContactFamilyName: family,
+		OrderValue:               req.OrderValue,
+		Currency:                 req.Currency,
+		Tip:                      req.Tip,
+		PickupTime:               req.PickupTime,
+		DropoffTime:              req.DropoffTime,
+	}
+	if req.Pickup.StoreID != "" {
+		d.PickupExternalBusinessID = p.businessID
+		d.PickupExternalStoreID = req.Pickup.StoreID
+	}
+	return d
+}
+
+func (p *doordashProvider) delivery(d *doordash.DeliveryInfo, err error) (*Delivery, error) {
+	if err != nil {
+		if apiErr, ok := err.(*doordash.Error); ok && apiErr.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return p.toDelivery(d), nil
+}
+
+func (p *doordashProvider) toDelivery(d *doordash.DeliveryInfo) *Delivery {
+	return &Delivery{
+		ID:          d.ExternalDeliveryID,
+		Provider:    p.Name(),
+		Status:      doordashStatus(d.DeliveryStatus),
+		Fee:         d.Fee,
+		Currency:    d.Currency,
+		TrackingURL: d.TrackingURL,
+		PickupETA:   d.PickupTimeEstimated,
+		DropoffETA:  d.DropoffTimeEstimated,
+		Raw:         d,
+	}
+}
+
+func doordashStatus(s string) Status {
+	if status, ok := doordashStatuses[s]; ok {
+		return status
+	}
+	return StatusUnknown
+}
+
+// splitName splits a contact name into given and family names at the first
+// space
+func splitName(name string) (string, string) {
+	parts := strings.SplitN(strings.TrimSpace(name), " ", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], strings.TrimSpace(parts[1])
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestDoorDash(t *testing.T) {
+	var created, updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/drive/v2/deliveries/D-slow":
+			// Hold the request until the caller gives up
+			select {
+			case <-req.Context().Done():
+			case <-time.After(time.Second):
+			}
+		case req.URL.Path == "/drive/v2/quotes":
+			rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "quote", "fee": 975, "currency": "USD"}`))
+		case req.URL.Path == "/drive/v2/deliveries":
+			json.NewDecoder(req.Body).Decode(&created)
+			rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "created", "fee": 975, "tracking_url": "https://doordash.com/tracking?id=1"}`))
+		case req.URL.Path == "/drive/v2/deliveries/D-1":
+			if req.Method == "PATCH" {
+				json.NewDecoder(req.Body).Decode(&updated)
+			}
+			rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "enroute_to_dropoff", "fee": 975}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code": "not_found", "message": "Delivery not found"}`))
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := doordash.NewClient("token", doordash.WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	p := NewDoorDash(c, "B-1")
+	ctx := context.Background()
+
+	req := &DeliveryRequest{
+		ID:      "D-1",
+		Pickup:  Location{Address: "901 Market Street", StoreID: "S-1"},
+		Dropoff: Location{Address: "1 Dr Carlton B Goodlett Pl", ContactName: "John van Doe", PhoneNumber: "+16505555555"},
+	}
+	q, err := p.Quote(ctx, req)
+	if err != nil || q.Fee != 975 {
+		t.Fatalf("unexpected quote %+v, error %v", q, err)
+	}
+	d, err := p.Create(ctx, req)
+	if err != nil || d.Status != StatusCreated || d.Provider != "doordash" {
+		t.Fatalf("unexpected delivery %+v, error %v", d, err)
+	}
+	if created["dropoff_contact_given_name"] != "John" || created["dropoff_contact_family_name"] != "van Doe" || created["pickup_external_business_id"] != "B-1" {
+		t.Errorf("unexpected delivery request %v", created)
+	}
+	if _, ok := d.Raw.(*doordash.DeliveryInfo); !ok {
+		t.Errorf("expected raw DoorDash delivery, got %T", d.Raw)
+	}
+
+	if d, _ := p.Get(ctx, "D-1"); d == nil || d.Status != StatusPickedUp {
+		t.Errorf("expected enroute_to_dropoff to map to picked_up, got %+v", d)
+	}
+	if _, err := p.Get(ctx, "D-9"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// Only the fields being changed are sent, including a zero tip
+	tip := 0
+	if _, err := p.Update(ctx, "D-1", &DeliveryUpdate{DropoffInstructions: "Leave at the door", Tip: &tip}); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	want := map[string]interface{}{"dropoff_instructions": "Leave at the door", "tip": float64(0)}
+	if !reflect.DeepEqual(updated, want) {
+		t.Errorf("expected update body %v, got %v", want, updated)
+	}
+	if _, err := p.Update(ctx, "D-9", &DeliveryUpdate{Tip: &tip}); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	e, err := p.ParseEvent(strings.NewReader(`{"event_name": "DELIVERY_DELIVERED", "created_at": "2023-05-01T18:00:00Z", "external_delivery_id": "D-1", "delivery_status": "delivered"}`))
+	if err != nil || e.Status != StatusDelivered || e.Type != "DELIVERY_DELIVERED" || e.DeliveryID != "D-1" || e.At.IsZero() {
+		t.Errorf("unexpected event %+v, error %v", e, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := p.Get(cancelled, "D-1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Cancel(timeout, "D-slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request in flight to be abandoned at the deadline, got %v", err)
+	}
+}
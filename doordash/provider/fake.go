@@ -0,0 +1,201 @@
+// In-memory provider for tests
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Fake is an in-memory DeliveryProvider for testing application code
+// without network access. Deliveries only change status when the test calls
+// SetStatus or Cancel.
+type Fake struct {
+	// Fee quoted and charged for every delivery, in cents
+	Fee int
+	// Time added to now for the dropoff ETA
+	DeliveryTime time.Duration
+	// Returned by every call when set, to test error handling
+	Err error
+	// Clock, defaults to time.Now
+	Now func() time.Time
+
+	mu         sync.Mutex
+	deliveries map[string]*Delivery
+	calls      []string
+}
+
+// Payload of the webhooks parsed by Fake.ParseEvent, as produced by
+// Fake.EventPayload
+type fakeEvent struct {
+	DeliveryID string    `json:"delivery_id"`
+	Status     Status    `json:"status"`
+	At         time.Time `json:"at"`
+}
+
+// NewFake returns a fake provider charging fee for every delivery
+func NewFake(fee int) *Fake {
+	return &Fake{Fee: fee, DeliveryTime: 30 * time.Minute}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Quote(ctx context.Context, req *DeliveryRequest) (*Quote, error) {
+	if err := f.call("Quote", req.ID); err != nil {
+		return nil, err
+	}
+	now := f.now()
+	return &Quote{ID: req.ID, Fee: f.Fee, Currency: req.Currency, PickupETA: now, DropoffETA: now.Add(f.DeliveryTime)}, nil
+}
+
+func (f *Fake) Create(ctx context.Context, req *DeliveryRequest) (*Delivery, error) {
+	if err := f.call("Create", req.ID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.deliveries[req.ID]; ok {
+		return nil, fmt.Errorf("delivery %s already exists", req.ID)
+	}
+	if f.deliveries == nil {
+		f.deliveries = map[string]*Delivery{}
+	}
+	now := f.now()
+	r := *req
+	d := &Delivery{
+		ID:          req.ID,
+		Provider:    f.Name(),
+		Status:      StatusCreated,
+		Fee:         f.Fee,
+		Currency:    req.Currency,
+		TrackingURL: "https://fake.invalid/track/" + req.ID,
+		PickupETA:   now,
+		DropoffETA:  now.Add(f.DeliveryTime),
+		Raw:         &r,
+	}
+	f.deliveries[req.ID] = d
+	return copyDelivery(d), nil
+}
+
+func (f *Fake) Get(ctx context.Context, id string) (*Delivery, error) {
+	if err := f.call("Get", id); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d, ok := f.deliveries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyDelivery(d), nil
+}
+
+// Update applies u to the request kept in the delivery's Raw field
+func (f *Fake) Update(ctx context.Context, id string, u *DeliveryUpdate) (*Delivery, error) {
+	if err := f.call("Update", id); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d, ok := f.deliveries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	// Copied so deliveries returned earlier keep their request
+	r := *d.Raw.(*DeliveryRequest)
+	if u.PickupInstructions != "" {
+		r.Pickup.Instructions = u.PickupInstructions
+	}
+	if u.DropoffInstructions != "" {
+		r.Dropoff.Instructions = u.DropoffInstructions
+	}
+	if u.DropoffPhoneNumber != "" {
+		r.Dropoff.PhoneNumber = u.DropoffPhoneNumber
+	}
+	if u.Tip != nil {
+		r.Tip = *u.Tip
+	}
+	d.Raw = &r
+	return copyDelivery(d), nil
+}
+
+func (f *Fake) Cancel(ctx context.Context, id string) (*Delivery, error) {
+	if err := f.call("Cancel", id); err != nil {
+		return nil, err
+	}
+	if err := f.SetStatus(id, StatusCancelled); err != nil {
+		return nil, err
+	}
+	return f.Get(ctx, id)
+}
+
+func (f *Fake) ParseEvent(r io.Reader) (*Event, error) {
+	e := &fakeEvent{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	event := &Event{DeliveryID: e.DeliveryID, Provider: f.Name(), Status: e.Status, Type: string(e.Status), At: e.At}
+	f.mu.Lock()
+	if d, ok := f.deliveries[e.DeliveryID]; ok {
+		event.Delivery = copyDelivery(d)
+	}
+	f.mu.Unlock()
+	return event, nil
+}
+
+// SetStatus moves a delivery to a new status, as the provider would
+func (f *Fake) SetStatus(id string, status Status) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d, ok := f.deliveries[id]
+	if !ok {
+		return ErrNotFound
+	}
+	d.Status = status
+	return nil
+}
+
+// EventPayload returns a webhook payload for the delivery's current status,
+// to be posted to a handler using this provider
+func (f *Fake) EventPayload(id string) ([]byte, error) {
+	f.mu.Lock()
+	d, ok := f.deliveries[id]
+	if !ok {
+		f.mu.Unlock()
+		return nil, ErrNotFound
+	}
+	e := &fakeEvent{DeliveryID: id, Status: d.Status, At: f.now()}
+	f.mu.Unlock()
+	return json.Marshal(e)
+}
+
+// Calls returns the calls made so far, e.g. "Create D-1"
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *Fake) call(method string, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, method+" "+id)
+	return f.Err
+}
+
+func (f *Fake) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+func copyDelivery(d *Delivery) *Delivery {
+	c := *d
+	return &c
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// dispatch is application code written against DeliveryProvider
+func dispatch(ctx context.Context, p DeliveryProvider, req *DeliveryRequest, maxFee int) (*Delivery, error) {
+	q, err := p.Quote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if q.Fee > maxFee {
+		return nil, errors.New("too expensive")
+	}
+	return p.Create(ctx, req)
+}
+
+func TestFake(t *testing.T) {
+	f := NewFake(975)
+	ctx := context.Background()
+
+	d, err := dispatch(ctx, f, &DeliveryRequest{ID: "D-1", Currency: "USD"}, 1000)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if d.Status != StatusCreated || d.Fee != 975 || d.Provider != "fake" {
+		t.Errorf("unexpected delivery %+v", d)
+	}
+	if _, err := dispatch(ctx, f, &DeliveryRequest{ID: "D-2"}, 500); err == nil {
+		t.Error("expected expensive delivery to be refused, got nil")
+	}
+	calls := f.Calls()
+	if len(calls) != 3 || calls[1] != "Create D-1" || calls[2] != "Quote D-2" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+
+	var events []*Event
+	handler := NewWebhookHandler(f, func(e *Event) error {
+		events = append(events, e)
+		return nil
+	})
+	f.SetStatus("D-1", StatusPickedUp)
+	payload, _ := f.EventPayload("D-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhooks/fake", bytes.NewReader(payload)))
+	if rec.Code != http.StatusOK || len(events) != 1 || events[0].Status != StatusPickedUp || events[0].Delivery == nil {
+		t.Errorf("unexpected webhook handling %d %+v", rec.Code, events)
+	}
+
+	tip := 300
+	updated, err := f.Update(ctx, "D-1", &DeliveryUpdate{DropoffInstructions: "Ring twice", Tip: &tip})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if r := updated.Raw.(*DeliveryRequest); r.Dropoff.Instructions != "Ring twice" || r.Tip != 300 || r.Currency != "USD" {
+		t.Errorf("expected update to be applied, got %+v", r)
+	}
+	if r := d.Raw.(*DeliveryRequest); r.Tip != 0 {
+		t.Errorf("expected earlier delivery to keep its request, got %+v", r)
+	}
+
+	if d, _ := f.Cancel(ctx, "D-1"); d.Status != StatusCancelled {
+		t.Errorf("expected delivery to be cancelled, got %s", d.Status)
+	}
+	if _, err := f.Get(ctx, "D-9"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	f.Err = errors.New("provider down")
+	if _, err := f.Get(ctx, "D-1"); err != f.Err {
+		t.Errorf("expected injected error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Package provider lets application code work with last-mile delivery
+// providers through one interface. Requests and deliveries use neutral
+// models; adapters such as DoorDash translate them to a provider's API, and
+// Fake keeps everything in memory for tests.
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash/internal/webhook"
+)
+
+var ErrNotFound = errors.New("delivery not found")
+
+// Provider-neutral delivery status
+type Status string
+
+const (
+	StatusQuoted    Status = "quoted"
+	StatusCreated   Status = "created"
+	StatusAssigned  Status = "assigned"
+	StatusPickedUp  Status = "picked_up"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+	StatusReturned  Status = "returned"
+	StatusUnknown   Status = "unknown"
+)
+
+type (
+	DeliveryProvider interface {
+		// Name identifies the provider, e.g. "doordash"
+		Name() string
+		// Quote prices a delivery without creating it
+		Quote(ctx context.Context, req *DeliveryRequest) (*Quote, error)
+		Create(ctx context.Context, req *DeliveryRequest) (*Delivery, error)
+		// Get returns a delivery or ErrNotFound
+		Get(ctx context.Context, id string) (*Delivery, error)
+		Update(ctx context.Context, id string, u *DeliveryUpdate) (*Delivery, error)
+		Cancel(ctx context.Context, id string) (*Delivery, error)
+		// ParseEvent normalizes a status webhook sent by the provider
+		ParseEvent(r io.Reader) (*Event, error)
+	}
+
+	// Pickup or dropoff point of a delivery
+	Location struct {
+		Address      string
+		BusinessName string
+		PhoneNumber  string
+		ContactName  string
+		Instructions string
+		// Provider's ID for a registered pickup location, if any
+		StoreID string
+	}
+
+	// Delivery to quote or create. Amounts are in cents.
+	DeliveryRequest struct {
+		// Caller's ID for the delivery, used to refer to it afterwards
+		ID         string
+		Pickup     Location
+		Dropoff    Location
+		OrderValue int
+		Currency   string
+		Tip        int
+		// Zero for as soon as possible
+		PickupTime  time.Time
+		DropoffTime time.Time
+	}
+
+	// Changes to a delivery. Empty fields and a nil Tip are left unchanged,
+	// so a tip can be set to zero.
+	DeliveryUpdate struct {
+		PickupInstructions  string
+		DropoffInstructions string
+		DropoffPhoneNumber  string
+		Tip                 *int
+	}
+
+	Quote struct {
+		ID         string
+		Fee        int
+		Currency   string
+		PickupETA  time.Time
+		DropoffETA time.Time
+	}
+
+	Delivery struct {
+		ID          string
+		Provider    string
+		Status      Status
+		Fee         int
+		Currency    string
+		TrackingURL string
+		PickupETA   time.Time
+		DropoffETA  time.Time
+		// Provider's own model, e.g. *doordash.DeliveryInfo
+		Raw interface{}
+	}
+
+	// Status change reported by a provider webhook
+	Event struct {
+		DeliveryID string
+		Provider   string
+		Status     Status
+		// Provider's own event name, e.g. "DASHER_PICKED_UP"
+		Type string
+		At   time.Time
+		// Delivery as reported by the event, if the provider sends one
+		Delivery *Delivery
+	}
+)
+
+// NewWebhookHandler returns an http.Handler that normalizes p's webhooks and
+// passes them to fn. Malformed payloads are answered with 400 and errors
+// returned by fn with 500 so the provider retries the event.
+func NewWebhookHandler(p DeliveryProvider, fn func(*Event) error) http.Handler {
+	return webhook.Handler(func(body io.Reader) error {
+		e, err := p.ParseEvent(body)
+		if err != nil {
+			return webhook.Malformed(err)
+		}
+		return fn(e)
+	})
+}
@@ -1,7 +1,10 @@
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Delivery
 package doordash
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Object for creating a delivery NewQuote
 type NewQuote struct {
@@ -32,12 +35,28 @@ type NewQuote struct {
 	Tip                             int        `json:"tip"`
 }
 
+// MarshalJSON leaves out unset pickup and dropoff times and windows, like
+// NewDelivery.MarshalJSON
+func (q NewQuote) MarshalJSON() ([]byte, error) {
+	type plain NewQuote
+	return json.Marshal(struct {
+		plain
+		PickupTime    *time.Time  `json:"pickup_time,omitempty"`
+		DropoffTime   *time.Time  `json:"dropoff_time,omitempty"`
+		PickupWindow  *TimeWindow `json:"pickup_window,omitempty"`
+		DropoffWindow *TimeWindow `json:"dropoff_window,omitempty"`
+	}{plain(q), timeOrNil(q.PickupTime), timeOrNil(q.DropoffTime), windowOrNil(q.PickupWindow), windowOrNil(q.DropoffWindow)})
+}
+
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/DeliveryQuote
-func (c *Client) CreateDeliveryQuote(q *NewQuote) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("POST", "drive/v2/quotes", q)
+func (c *Client) CreateDeliveryQuote(q *NewQuote, opts ...CallOption) (*DeliveryInfo, error) {
+	if err := c.checkStore(callContext(opts), q.PickupExternalBusinessID, q.PickupExternalStoreID); err != nil {
+		return nil, err
+	}
+	return c.makeDeliveryRequest("CreateDeliveryQuote", "POST", "drive/v2/quotes", q, opts...)
 }
 
 // API Doc: https://developer.doordash.com/en-US/api/drive#tag/Delivery/operation/DeliveryQuoteAccept
-func (c *Client) AcceptDeliveryQuote(externalDeliveryID string) (*DeliveryInfo, error) {
-	return c.makeDeliveryRequest("POST", ("drive/v2/quotes" + externalDeliveryID + "/accept"), nil)
+func (c *Client) AcceptDeliveryQuote(externalDeliveryID string, opts ...CallOption) (*DeliveryInfo, error) {
+	return c.makeDeliveryRequest("AcceptDeliveryQuote", "POST", ("drive/v2/quotes/" + externalDeliveryID + "/accept"), nil, opts...)
 }
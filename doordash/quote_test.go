@@ -54,7 +54,7 @@ func TestCreateDeliveryQuote(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.CreateDeliveryQuote(payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -72,7 +72,7 @@ func TestAcceptDeliveryQuote(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		// Test request parameters
 		reqURL := req.URL.String()
-		if reqURL != ("/drive/v2/quotes" + testID + "/accept") {
+		if reqURL != ("/drive/v2/quotes/" + testID + "/accept") {
 			t.Errorf("expected request URL to be /drive/v2/quotes/%s/accept, got %s", testID, reqURL)
 		}
 		// Send response to be tested
@@ -82,7 +82,7 @@ func TestAcceptDeliveryQuote(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.AcceptDeliveryQuote(testID)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
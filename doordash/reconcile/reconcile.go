@@ -0,0 +1,255 @@
+// Package reconcile checks DoorDash billing against locally recorded
+// deliveries. Billing lines from a financial report or transaction export
+// are matched to repository records by external delivery ID, falling back to
+// the support reference, and every delivery whose charges do not add up is
+// flagged in a machine-readable report.
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+	"github.com/alext251/doordash-go-sdk/doordash/repository"
+)
+
+// Kind of problem found for a delivery
+type IssueKind string
+
+const (
+	// The billed fee differs from the fee recorded when the delivery was made
+	FeeMismatch IssueKind = "fee_mismatch"
+	TipMismatch IssueKind = "tip_mismatch"
+	// A cancellation was charged
+	CancellationCharge IssueKind = "cancellation_charge"
+	// Billing refers to a delivery with no local record
+	MissingRecord IssueKind = "missing_record"
+	// A recorded delivery within Options.From and Options.To was not billed
+	Unbilled IssueKind = "unbilled"
+	// A billing line of a type the reconciler does not know
+	UnknownTransaction IssueKind = "unknown_transaction"
+)
+
+type Issue struct {
+	Kind    IssueKind `json:"kind"`
+	Message string    `json:"message"`
+}
+
+// Billing of a single delivery compared with its local record. Amounts are
+// in cents.
+type Delivery struct {
+	ExternalDeliveryID string `json:"external_delivery_id"`
+	SupportReference   string `json:"support_reference,omitempty"`
+	Recorded           bool   `json:"recorded"`
+	RecordedStatus     string `json:"recorded_status,omitempty"`
+	RecordedFee        int    `json:"recorded_fee"`
+	BilledFee          int    `json:"billed_fee"`
+	RecordedTip        int    `json:"recorded_tip"`
+	BilledTip          int    `json:"billed_tip"`
+	CancellationFee    int    `json:"cancellation_fee"`
+	// Refunds, credits and other adjustments
+	Adjustments int     `json:"adjustments"`
+	Issues      []Issue `json:"issues,omitempty"`
+}
+
+type Report struct {
+	GeneratedAt  time.Time `json:"generated_at"`
+	Transactions int       `json:"transactions"`
+	// Deliveries billed or expected to be billed, in external delivery ID
+	// order
+	Deliveries []*Delivery `json:"deliveries"`
+	// Number of deliveries with at least one issue
+	Flagged int `json:"flagged"`
+}
+
+type Options struct {
+	// Difference in cents allowed between billed and recorded amounts
+	Tolerance int
+	// When set, recorded deliveries first seen within [From, To) that were
+	// not billed are flagged as Unbilled. Cancelled deliveries are expected
+	// not to be billed.
+	From time.Time
+	To   time.Time
+	// Clock, defaults to time.Now
+	Now func() time.Time
+}
+
+// ReconcileCSV reads a financial report or transaction export, as parsed by
+// doordash.ReadFinancialReport, and reconciles it against repo
+func ReconcileCSV(repo repository.DeliveryRepository, r io.Reader, opts Options) (*Report, error) {
+	transactions, err := doordash.ReadFinancialReport(r)
+	if err != nil {
+		return nil, err
+	}
+	return Reconcile(repo, transactions, opts)
+}
+
+// Reconcile matches billing lines to recorded deliveries and flags
+// discrepancies
+func Reconcile(repo repository.DeliveryRepository, transactions []doordash.FinancialReportRow, opts Options) (*Report, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	// Lines identified only by support reference need an index of records
+	all, err := repo.Find(repository.Query{})
+	if err != nil {
+		return nil, err
+	}
+	bySupportRef := map[string]*repository.Record{}
+	for _, r := range all {
+		if ref := r.Delivery.SupportReference; ref != "" {
+			bySupportRef[ref] = r
+		}
+	}
+
+	deliveries := map[string]*Delivery{}
+	records := map[string]*repository.Record{}
+	for i, t := range transactions {
+		record, err := findRecord(repo, bySupportRef, t)
+		if err != nil {
+			return nil, err
+		}
+
+		key := t.ExternalDeliveryID
+		if record != nil {
+			key = record.ExternalDeliveryID
+		}
+		if key == "" {
+			key = "support:" + t.SupportReference
+		}
+		if t.ExternalDeliveryID == "" && t.SupportReference == "" {
+			key = fmt.Sprintf("line:%d", i+2)
+		}
+
+		d, ok := deliveries[key]
+		if !ok {
+			d = &Delivery{ExternalDeliveryID: externalID(record, t), SupportReference: t.SupportReference}
+			deliveries[key] = d
+			records[key] = record
+		}
+		addTransaction(d, t)
+	}
+
+	for key, d := range deliveries {
+		compare(d, records[key], opts.Tolerance)
+	}
+	if !opts.From.IsZero() || !opts.To.IsZero() {
+		expected, err := repo.Find(repository.Query{From: opts.From, To: opts.To})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range expected {
+			if _, ok := deliveries[r.ExternalDeliveryID]; ok || r.Delivery.DeliveryStatus == "cancelled" {
+				continue
+			}
+			d := &Delivery{ExternalDeliveryID: r.ExternalDeliveryID, SupportReference: r.Delivery.SupportReference}
+			compare(d, r, opts.Tolerance)
+			d.Issues = []Issue{{Kind: Unbilled, Message: fmt.Sprintf("%s delivery with fee %d was not billed", r.Delivery.DeliveryStatus, r.Delivery.Fee)}}
+			deliveries[r.ExternalDeliveryID] = d
+		}
+	}
+
+	report := &Report{GeneratedAt: now(), Transactions: len(transactions)}
+	for _, d := range deliveries {
+		report.Deliveries = append(report.Deliveries, d)
+		if len(d.Issues) > 0 {
+			report.Flagged++
+		}
+	}
+	sort.Slice(report.Deliveries, func(i, j int) bool {
+		a, b := report.Deliveries[i], report.Deliveries[j]
+		if a.ExternalDeliveryID != b.ExternalDeliveryID {
+			return a.ExternalDeliveryID < b.ExternalDeliveryID
+		}
+		return a.SupportReference < b.SupportReference
+	})
+	return report, nil
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func findRecord(repo repository.DeliveryRepository, bySupportRef map[string]*repository.Record, t doordash.FinancialReportRow) (*repository.Record, error) {
+	if t.ExternalDeliveryID != "" {
+		r, err := repo.Get(t.ExternalDeliveryID)
+		if err == nil {
+			return r, nil
+		}
+		if err != repository.ErrNotFound {
+			return nil, err
+		}
+	}
+	return bySupportRef[t.SupportReference], nil
+}
+
+func addTransaction(d *Delivery, t doordash.FinancialReportRow) {
+	switch kind := strings.ToLower(t.TransactionType); {
+	case strings.Contains(kind, "cancel"):
+		d.CancellationFee += t.Amount
+	case strings.Contains(kind, "tip"):
+		d.BilledTip += t.Amount
+	case strings.Contains(kind, "refund"), strings.Contains(kind, "credit"), strings.Contains(kind, "adjust"):
+		d.Adjustments += t.Amount
+	case strings.Contains(kind, "fee"), strings.Contains(kind, "delivery"):
+		d.BilledFee += t.Amount
+	default:
+		d.Adjustments += t.Amount
+		d.Issues = append(d.Issues, Issue{Kind: UnknownTransaction, Message: fmt.Sprintf("unknown transaction type %q of %d", t.TransactionType, t.Amount)})
+	}
+}
+
+// compare fills in the recorded amounts and flags differences from billing
+func compare(d *Delivery, r *repository.Record, tolerance int) {
+	if r == nil {
+		d.Issues = append(d.Issues, Issue{Kind: MissingRecord, Message: "billed delivery has no local record"})
+		return
+	}
+	d.Recorded = true
+	d.RecordedStatus = r.Delivery.DeliveryStatus
+	d.RecordedFee = r.Delivery.Fee
+	d.RecordedTip = r.Delivery.Tip
+	if d.SupportReference == "" {
+		d.SupportReference = r.Delivery.SupportReference
+	}
+
+	if d.CancellationFee != 0 {
+		msg := fmt.Sprintf("cancellation charged %d", d.CancellationFee)
+		if d.RecordedStatus != "cancelled" {
+			msg += fmt.Sprintf(" but delivery is recorded as %s", d.RecordedStatus)
+		}
+		d.Issues = append(d.Issues, Issue{Kind: CancellationCharge, Message: msg})
+	}
+	// A cancelled delivery is billed its cancellation fee, not its quoted fee
+	if d.RecordedStatus != "cancelled" && abs(d.BilledFee-d.RecordedFee) > tolerance {
+		d.Issues = append(d.Issues, Issue{Kind: FeeMismatch, Message: fmt.Sprintf("billed fee %d, recorded %d", d.BilledFee, d.RecordedFee)})
+	}
+	if abs(d.BilledTip-d.RecordedTip) > tolerance && !(d.RecordedStatus == "cancelled" && d.BilledTip == 0) {
+		d.Issues = append(d.Issues, Issue{Kind: TipMismatch, Message: fmt.Sprintf("billed tip %d, recorded %d", d.BilledTip, d.RecordedTip)})
+	}
+}
+
+// externalID prefers the recorded ID, as billing lines matched by support
+// reference may not carry one
+func externalID(r *repository.Record, t doordash.FinancialReportRow) string {
+	if r != nil {
+		return r.ExternalDeliveryID
+	}
+	return t.ExternalDeliveryID
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+package reconcile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+	"github.com/alext251/doordash-go-sdk/doordash/repository"
+)
+
+const billingCSV = `external_delivery_id,support_reference,transaction_type,amount,currency
+D-1,1001,delivery_fee,9.75,USD
+D-1,1001,tip,2.00,USD
+D-2,1002,delivery_fee,12.50,USD
+,1003,delivery_fee,8.00,USD
+D-4,1004,cancellation_fee,5.00,USD
+D-9,1009,delivery_fee,7.00,USD
+D-1,1001,mystery,1.00,USD
+`
+
+func TestReconcile(t *testing.T) {
+	t0 := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewMemory()
+	for _, d := range []*doordash.DeliveryInfo{
+		{ExternalDeliveryID: "D-1", SupportReference: "1001", DeliveryStatus: "delivered", Fee: 975, Tip: 200},
+		{ExternalDeliveryID: "D-2", SupportReference: "1002", DeliveryStatus: "delivered", Fee: 975},
+		{ExternalDeliveryID: "D-3", SupportReference: "1003", DeliveryStatus: "delivered", Fee: 800},
+		{ExternalDeliveryID: "D-4", SupportReference: "1004", DeliveryStatus: "cancelled", Fee: 975},
+		{ExternalDeliveryID: "D-5", SupportReference: "1005", DeliveryStatus: "delivered", Fee: 975},
+	} {
+		repo.Save(d, "test", t0)
+	}
+
+	report, err := ReconcileCSV(repo, strings.NewReader(billingCSV), Options{
+		From: t0.Add(-time.Hour),
+		To:   t0.Add(time.Hour),
+		Now:  func() time.Time { return t0 },
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	want := map[string][]IssueKind{
+		"D-1": {UnknownTransaction},
+		"D-2": {FeeMismatch},
+		"D-3": nil,
+		"D-4": {CancellationCharge},
+		"D-5": {Unbilled},
+		"D-9": {MissingRecord},
+	}
+	if len(report.Deliveries) != len(want) || report.Transactions != 7 || report.Flagged != 5 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	for _, d := range report.Deliveries {
+		kinds := []IssueKind{}
+		for _, issue := range d.Issues {
+			kinds = append(kinds, issue.Kind)
+		}
+		if len(kinds) != len(want[d.ExternalDeliveryID]) || (len(kinds) > 0 && kinds[0] != want[d.ExternalDeliveryID][0]) {
+			t.Errorf("%s: expected issues %v, got %v", d.ExternalDeliveryID, want[d.ExternalDeliveryID], d.Issues)
+		}
+	}
+	if d := report.Deliveries[2]; d.ExternalDeliveryID != "D-3" || d.BilledFee != 800 || !d.Recorded {
+		t.Errorf("expected line without delivery ID matched by support reference, got %+v", d)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := report.WriteJSON(buf); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	decoded := &Report{}
+	if err := json.Unmarshal(buf.Bytes(), decoded); err != nil || decoded.Flagged != 5 || decoded.Deliveries[1].BilledFee != 1250 {
+		t.Errorf("unexpected JSON report %s", buf)
+	}
+
+	report, _ = ReconcileCSV(repo, strings.NewReader("external_delivery_id,transaction_type,amount\nD-2,delivery_fee,12.50\n"), Options{Tolerance: 500})
+	if len(report.Deliveries) != 1 || len(report.Deliveries[0].Issues) != 0 {
+		t.Errorf("expected difference within tolerance not to be flagged, got %+v", report.Deliveries)
+	}
+}
@@ -0,0 +1,73 @@
+package recorder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	statuses := []string{"created", "delivered"}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			rw.Write([]byte(`{"external_delivery_id":"D-1","delivery_status":"created","dropoff_phone_number":"+16505555555","fee":975}`))
+			return
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		rw.Write([]byte(`{"external_delivery_id":"D-1","delivery_status":"` + status + `"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL + "/")
+
+	rec := NewRecorder(server.Client().Transport)
+	live := doordash.NewClient("secret-token", doordash.WithHTTPClient(&http.Client{Transport: rec}))
+	live.BaseURL = baseURL
+	delivery := &doordash.NewDelivery{ExternalDeliveryID: "D-1", DropoffPhoneNumber: "+16505555555", OrderValue: 1999}
+	if _, err := live.CreateDelivery(delivery); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	live.GetDeliveryStatus("D-1")
+	live.GetDeliveryStatus("D-1")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "secret-token") || strings.Contains(string(data), "6505555555") {
+		t.Errorf("expected token and phone number to be redacted, got %s", data)
+	}
+
+	replay, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	offline := doordash.NewClient("other-token", doordash.WithHTTPClient(&http.Client{Transport: replay}))
+	offline.BaseURL = baseURL
+
+	created, err := offline.CreateDelivery(delivery)
+	if err != nil || created.Fee != 975 || created.DropoffPhoneNumber != Redacted {
+		t.Errorf("unexpected replayed delivery %+v, error %v", created, err)
+	}
+	first, _ := offline.GetDeliveryStatus("D-1")
+	second, _ := offline.GetDeliveryStatus("D-1")
+	if first == nil || second == nil || first.DeliveryStatus != "created" || second.DeliveryStatus != "delivered" {
+		t.Errorf("expected polled statuses to be replayed in order, got %+v and %+v", first, second)
+	}
+	if len(replay.Unused()) != 0 {
+		t.Errorf("expected all interactions to be replayed, got %d unused", len(replay.Unused()))
+	}
+
+	delivery.OrderValue = 2500
+	_, err = offline.CreateDelivery(delivery)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction for POST /drive/v2/deliveries") {
+		t.Errorf("expected unmatched request error, got %v", err)
+	}
+}
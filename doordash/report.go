@@ -0,0 +1,299 @@
+// API Spec: https://developer.doordash.com/en-US/api/drive#tag/Reports
+package doordash
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReportTypeDeliveries = "delivery_details"
+	ReportTypeFinancials = "financial_details"
+
+	ReportStatusPending    = "pending"
+	ReportStatusProcessing = "processing"
+	ReportStatusSucceeded  = "succeeded"
+	ReportStatusFailed     = "failed"
+
+	defaultReportPollInterval = 10 * time.Second
+)
+
+// Object for requesting a report. Dates are inclusive, in "YYYY-MM-DD" format.
+type NewReport struct {
+	ReportType         string `json:"report_type"`
+	StartDate          string `json:"start_date"`
+	EndDate            string `json:"end_date"`
+	ExternalBusinessID string `json:"external_business_id,omitempty"`
+}
+
+// Object containing response information for reports
+type ReportInfo struct {
+	ReportID    string    `json:"report_id"`
+	ReportType  string    `json:"report_type"`
+	Status      string    `json:"status"`
+	DownloadURL string    `json:"download_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *ReportInfo) UnmarshalJSON(data []byte) error {
+	type plain ReportInfo
+	return unmarshalWithExtra(data, (*plain)(r), &r.Extra)
+}
+
+func (r ReportInfo) MarshalJSON() ([]byte, error) {
+	type plain ReportInfo
+	return marshalWithExtra(plain(r), r.Extra)
+}
+
+func (r *ReportInfo) unknownFields() []string {
+	return extraKeys("", r.Extra)
+}
+
+// Row of a delivery report. Amounts are in cents; fields tagged
+// report:"dollars" are written in dollars in the CSV and converted.
+type DeliveryReportRow struct {
+	ExternalDeliveryID    string    `json:"external_delivery_id"`
+	SupportReference      string    `json:"support_reference"`
+	PickupExternalStoreID string    `json:"pickup_external_store_id"`
+	DeliveryStatus        string    `json:"delivery_status"`
+	CreatedAt             time.Time `json:"created_at"`
+	DropoffTimeActual     time.Time `json:"dropoff_time_actual"`
+	OrderValue            int       `json:"order_value" report:"dollars"`
+	Fee                   int       `json:"fee" report:"dollars"`
+	Tip                   int       `json:"tip" report:"dollars"`
+	Currency              string    `json:"currency"`
+}
+
+// Row of a financial report, one per charge or credit. Amounts are in cents,
+// converted from dollars like DeliveryReportRow; credits are negative.
+type FinancialReportRow struct {
+	ExternalDeliveryID string    `json:"external_delivery_id"`
+	SupportReference   string    `json:"support_reference"`
+	TransactionType    string    `json:"transaction_type"`
+	TransactionDate    time.Time `json:"transaction_date"`
+	Amount             int       `json:"amount" report:"dollars"`
+	Currency           string    `json:"currency"`
+	Description        string    `json:"description"`
+}
+
+// Returned when DoorDash could not generate a report
+type ReportFailedError struct {
+	ReportID string
+}
+
+func (e *ReportFailedError) Error() string {
+	return fmt.Sprintf("doordash: report %s failed", e.ReportID)
+}
+
+// CreateReport requests a report, which is generated asynchronously
+//
+// API Spec: https://developer.doordash.com/en-US/api/drive#tag/Reports/operation/CreateReport
+func (c *Client) CreateReport(r *NewReport, opts ...CallOption) (*ReportInfo, error) {
+	res := &ReportInfo{}
+	if err := c.makeRequest("CreateReport", "POST", "drive/v2/reports", nil, r, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// API Spec: https://developer.doordash.com/en-US/api/drive#tag/Reports/operation/GetReport
+func (c *Client) GetReport(reportID string, opts ...CallOption) (*ReportInfo, error) {
+	res := &ReportInfo{}
+	if err := c.makeRequest("GetReport", "GET", ("drive/v2/reports/" + reportID), nil, nil, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WaitForReport polls a report every interval, 10 seconds if zero, until it
+// has succeeded. A failed report is returned as *ReportFailedError.
+func (c *Client) WaitForReport(ctx context.Context, reportID string, interval time.Duration) (*ReportInfo, error) {
+	if interval <= 0 {
+		interval = defaultReportPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res := &ReportInfo{}
+		if err := c.makeRequestContext(ctx, "GetReport", "GET", ("drive/v2/reports/" + reportID), nil, nil, res); err != nil {
+			return nil, err
+		}
+		switch res.Status {
+		case ReportStatusSucceeded:
+			return res, nil
+		case ReportStatusFailed:
+			return nil, &ReportFailedError{ReportID: reportID}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DownloadReport downloads a finished report and returns its CSV content,
+// decompressed if the file is gzipped or zipped. The download URL is
+// pre-signed, so no credentials are sent with it.
+func (c *Client) DownloadReport(ctx context.Context, report *ReportInfo) ([]byte, error) {
+	if report.DownloadURL == "" {
+		return nil, fmt.Errorf("report %s has no download URL", report.ReportID)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", report.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &Error{StatusCode: res.StatusCode}
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decompressReport(data)
+}
+
+// ReadDeliveryReport parses a delivery report CSV. Columns are matched to
+// fields by their JSON names; unknown columns are ignored so new report
+// columns do not break parsing. Amount columns are read as dollars, so "12"
+// is $12.00.
+func ReadDeliveryReport(r io.Reader) ([]DeliveryReportRow, error) {
+	var rows []DeliveryReportRow
+	err := readReportCSV(r, reflect.TypeOf(DeliveryReportRow{}), func(v reflect.Value) {
+		rows = append(rows, v.Interface().(DeliveryReportRow))
+	})
+	return rows, err
+}
+
+// ReadFinancialReport parses a financial report CSV, like ReadDeliveryReport
+func ReadFinancialReport(r io.Reader) ([]FinancialReportRow, error) {
+	var rows []FinancialReportRow
+	err := readReportCSV(r, reflect.TypeOf(FinancialReportRow{}), func(v reflect.Value) {
+		rows = append(rows, v.Interface().(FinancialReportRow))
+	})
+	return rows, err
+}
+
+func decompressReport(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if !strings.HasSuffix(strings.ToLower(f.Name), ".csv") {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("report archive contains no CSV file")
+	default:
+		return data, nil
+	}
+}
+
+func readReportCSV(r io.Reader, rowType reflect.Type, add func(reflect.Value)) error {
+	// Reports may start with a byte order mark
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		br.Discard(3)
+	}
+	cr := csv.NewReader(br)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading CSV header: %v", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	// The unit of amount columns comes from the row type, never from the
+	// cells, so whole-dollar amounts are not mistaken for cents
+	dollars := make([]bool, len(header))
+	for i, name := range header {
+		for j := 0; j < rowType.NumField(); j++ {
+			if f := rowType.Field(j); strings.Split(f.Tag.Get("json"), ",")[0] == name {
+				dollars[i] = f.Tag.Get("report") == "dollars"
+			}
+		}
+	}
+
+	for n, record := range records {
+		line := n + 2
+		row := reflect.New(rowType).Elem()
+		for i, raw := range record {
+			field, err := fieldByPath(row, header[i])
+			if raw == "" || err != nil {
+				continue
+			}
+			if dollars[i] {
+				raw, err = dollarsToCents(raw)
+				if err != nil {
+					return fmt.Errorf("line %d, column %s: %v", line, header[i], err)
+				}
+			}
+			if err := setField(field, raw); err != nil {
+				return fmt.Errorf("line %d, column %s: %v", line, header[i], err)
+			}
+		}
+		add(row)
+	}
+	return nil
+}
+
+// dollarsToCents converts a decimal amount such as "12.34" to cents
+func dollarsToCents(raw string) (string, error) {
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount %q", raw)
+	}
+	return strconv.Itoa(int(math.Round(f * 100))), nil
+}
@@ -0,0 +1,121 @@
+package doordash
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const deliveryReportCSV = "\xef\xbb\xbfexternal_delivery_id,support_reference,delivery_status,created_at,fee,tip,dasher_rating\n" +
+	"D-1,86313,delivered,2023-05-01T17:00:00Z,9.75,2,5\n" +
+	"D-2,86314,cancelled,2023-05-01T18:00:00Z,0,,\n"
+
+func TestReportLifecycle(t *testing.T) {
+	polls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/drive/v2/reports":
+			rw.Write([]byte(`{"report_id": "R-1", "report_type": "delivery_details", "status": "pending"}`))
+		case req.URL.Path == "/drive/v2/reports/R-1":
+			polls++
+			if polls < 3 {
+				rw.Write([]byte(`{"report_id": "R-1", "status": "processing"}`))
+				return
+			}
+			rw.Write([]byte(`{"report_id": "R-1", "status": "succeeded", "download_url": "` + server.URL + `/download/R-1.csv.gz"}`))
+		case req.URL.Path == "/drive/v2/reports/R-2":
+			rw.Write([]byte(`{"report_id": "R-2", "status": "failed"}`))
+		case req.URL.Path == "/download/R-1.csv.gz":
+			if req.Header.Get("Authorization") != "" {
+				t.Error("expected no credentials sent with the download")
+			}
+			zw := gzip.NewWriter(rw)
+			zw.Write([]byte(deliveryReportCSV))
+			zw.Close()
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	ctx := context.Background()
+
+	report, err := c.CreateReport(&NewReport{ReportType: ReportTypeDeliveries, StartDate: "2023-05-01", EndDate: "2023-05-31"})
+	if err != nil || report.ReportID != "R-1" || report.Status != ReportStatusPending {
+		t.Fatalf("unexpected report %+v, error %v", report, err)
+	}
+	report, err = c.WaitForReport(ctx, "R-1", 1)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if polls != 3 || report.Status != ReportStatusSucceeded {
+		t.Errorf("expected report to succeed after 3 polls, got %+v after %d", report, polls)
+	}
+
+	data, err := c.DownloadReport(ctx, report)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	rows, err := ReadDeliveryReport(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if len(rows) != 2 || rows[0].ExternalDeliveryID != "D-1" || rows[0].Fee != 975 || rows[0].Tip != 200 || rows[0].CreatedAt.IsZero() {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+	if rows[1].DeliveryStatus != "cancelled" || rows[1].Tip != 0 {
+		t.Errorf("unexpected row %+v", rows[1])
+	}
+
+	if _, err := c.WaitForReport(ctx, "R-2", 1); err == nil || err.Error() != "doordash: report R-2 failed" {
+		t.Errorf("expected failed report error, got %v", err)
+	}
+}
+
+func TestReadFinancialReportZip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	f, _ := zw.Create("financials.csv")
+	f.Write([]byte("External_Delivery_ID,transaction_type,amount,currency\nD-1,delivery_fee,9.75,USD\nD-1,refund,-2.50,USD\n"))
+	zw.Close()
+
+	data, err := decompressReport(buf.Bytes())
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	rows, err := ReadFinancialReport(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if len(rows) != 2 || rows[0].ExternalDeliveryID != "D-1" || rows[0].Amount != 975 || rows[1].Amount != -250 {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+
+	// A column of whole dollars is still in dollars
+	rows, err = ReadFinancialReport(strings.NewReader("amount,transaction_date\n12,2023-05-01\n7,2023-05-02T10:00:00Z\n"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if len(rows) != 2 || rows[0].Amount != 1200 || rows[1].Amount != 700 {
+		t.Errorf("expected both amounts in dollars, got %+v", rows)
+	}
+	if want := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC); !rows[0].TransactionDate.Equal(want) {
+		t.Errorf("expected date-only cell to be %v, got %v", want, rows[0].TransactionDate)
+	}
+
+	if _, err := ReadFinancialReport(strings.NewReader("amount\nabc\n")); err == nil {
+		t.Error("expected invalid amount to fail, got nil")
+	}
+}
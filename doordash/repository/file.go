@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+	"github.com/alext251/doordash-go-sdk/doordash/internal/jsonfile"
+)
+
+// DeliveryRepository persisted to a JSON file. Records are kept in memory and
+// the whole file is rewritten atomically on every save, which suits the
+// modest volumes of a single integration; larger deployments should
+// implement DeliveryRepository on top of their own database.
+type File struct {
+	*Memory
+	path string
+}
+
+// OpenFile loads the repository stored at path, starting empty if the file
+// does not exist yet
+func OpenFile(path string) (*File, error) {
+	f := &File{Memory: NewMemory(), path: path}
+	var records []*Record
+	if err := jsonfile.Load(path, &records); err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		f.records[r.ExternalDeliveryID] = r
+	}
+	return f, nil
+}
+
+func (f *File) Save(d *doordash.DeliveryInfo, source string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous, existed := f.records[d.ExternalDeliveryID]
+	var backup *Record
+	if existed {
+		backup = copyRecord(previous)
+	}
+	f.records[d.ExternalDeliveryID] = apply(previous, d, source, at)
+
+	records := make([]*Record, 0, len(f.records))
+	for _, r := range f.records {
+		records = append(records, r)
+	}
+	return jsonfile.Commit(f.path, records, func() {
+		if existed {
+			f.records[d.ExternalDeliveryID] = backup
+		} else {
+			delete(f.records, d.ExternalDeliveryID)
+		}
+	})
+}
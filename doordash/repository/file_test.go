@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestFilePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deliveries.json")
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if err := f.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", DeliveryStatus: "created", Fee: 975}, "CreateDelivery", t0); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	want, _ := f.Get("D-1")
+
+	reopened, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	got, err := reopened.Get("D-1")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected reopened record to be %v, got %v", want, got)
+	}
+}
+
+func TestFileSaveFailure(t *testing.T) {
+	dir := t.TempDir()
+	f, _ := OpenFile(filepath.Join(dir, "missing", "deliveries.json"))
+
+	if err := f.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1"}, "test", t0); err == nil {
+		t.Fatal("expected error writing to a missing directory, got nil")
+	}
+	if _, err := f.Get("D-1"); err != ErrNotFound {
+		t.Errorf("expected failed save to be rolled back, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0600)
+	if _, err := OpenFile(filepath.Join(dir, "corrupt.json")); err == nil {
+		t.Error("expected error opening a corrupt file, got nil")
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// In-memory DeliveryRepository, safe for concurrent use
+type Memory struct {
+	mu      sync.RWMutex
+	records map[string]*Record
+}
+
+func NewMemory() *Memory {
+	return &Memory{records: map[string]*Record{}}
+}
+
+func (m *Memory) Save(d *doordash.DeliveryInfo, source string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[d.ExternalDeliveryID] = apply(m.records[d.ExternalDeliveryID], d, source, at)
+	return nil
+}
+
+func (m *Memory) Get(externalDeliveryID string) (*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.records[externalDeliveryID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyRecord(r), nil
+}
+
+func (m *Memory) Find(q Query) ([]*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found []*Record
+	for _, r := range m.records {
+		if q.matches(r) {
+			found = append(found, copyRecord(r))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].CreatedAt.Equal(found[j].CreatedAt) {
+			return found[i].ExternalDeliveryID < found[j].ExternalDeliveryID
+		}
+		return found[i].CreatedAt.Before(found[j].CreatedAt)
+	})
+	return found, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var t0 = time.Date(2022, 4, 25, 17, 0, 0, 0, time.UTC)
+
+func TestMemorySaveAndGet(t *testing.T) {
+	m := NewMemory()
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", DeliveryStatus: "created"}, "CreateDelivery", t0)
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", DeliveryStatus: "created"}, "GetDeliveryStatus", t0.Add(time.Minute))
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", DeliveryStatus: "picked_up"}, "webhook:DASHER_PICKED_UP", t0.Add(20*time.Minute))
+	// A late webhook for an earlier status
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", DeliveryStatus: "confirmed"}, "webhook:DASHER_CONFIRMED", t0.Add(5*time.Minute))
+
+	got, err := m.Get("D-1")
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	if got.Delivery.DeliveryStatus != "picked_up" {
+		t.Errorf("expected latest snapshot to win, got %q", got.Delivery.DeliveryStatus)
+	}
+	if !got.CreatedAt.Equal(t0) || !got.UpdatedAt.Equal(t0.Add(20*time.Minute)) {
+		t.Errorf("unexpected timestamps %v and %v", got.CreatedAt, got.UpdatedAt)
+	}
+
+	want := []StatusChange{
+		{Status: "created", At: t0, Source: "CreateDelivery"},
+		{Status: "confirmed", At: t0.Add(5 * time.Minute), Source: "webhook:DASHER_CONFIRMED"},
+		{Status: "picked_up", At: t0.Add(20 * time.Minute), Source: "webhook:DASHER_PICKED_UP"},
+	}
+	if !reflect.DeepEqual(got.History, want) {
+		t.Errorf("expected history to be %v, got %v", want, got.History)
+	}
+
+	if _, err := m.Get("D-2"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryFind(t *testing.T) {
+	m := NewMemory()
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", PickupExternalStoreID: "S-1", DeliveryStatus: "delivered"}, "test", t0)
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-2", PickupExternalStoreID: "S-1", DeliveryStatus: "created"}, "test", t0.Add(time.Hour))
+	m.Save(&doordash.DeliveryInfo{ExternalDeliveryID: "D-3", PickupExternalStoreID: "S-2", DeliveryStatus: "delivered"}, "test", t0.Add(2*time.Hour))
+
+	tests := []struct {
+		query Query
+		want  []string
+	}{
+		{query: Query{}, want: []string{"D-1", "D-2", "D-3"}},
+		{query: Query{ExternalStoreID: "S-1"}, want: []string{"D-1", "D-2"}},
+		{query: Query{Status: "delivered"}, want: []string{"D-1", "D-3"}},
+		{query: Query{From: t0.Add(time.Hour), To: t0.Add(2 * time.Hour)}, want: []string{"D-2"}},
+		{query: Query{ExternalStoreID: "S-3"}, want: nil},
+	}
+	for _, tt := range tests {
+		records, err := m.Find(tt.query)
+		if err != nil {
+			t.Fatalf("expected error to be nil, got %v", err)
+		}
+		var got []string
+		for _, r := range records {
+			got = append(got, r.ExternalDeliveryID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package repository keeps a local record of deliveries keyed by
+// ExternalDeliveryID, along with the history of their status changes.
+package repository
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var ErrNotFound = errors.New("delivery not found")
+
+type (
+	// Persistence for delivery snapshots
+	DeliveryRepository interface {
+		// Save records a snapshot of a delivery seen at the given time. The
+		// snapshot replaces the stored delivery and a status change is added
+		// to its history when the status differs from the previous one.
+		Save(d *doordash.DeliveryInfo, source string, at time.Time) error
+		// Get returns the record of a delivery or ErrNotFound
+		Get(externalDeliveryID string) (*Record, error)
+		// Find returns the records matching the query, oldest first
+		Find(q Query) ([]*Record, error)
+	}
+
+	// A delivery along with its status history
+	Record struct {
+		ExternalDeliveryID string                `json:"external_delivery_id"`
+		Delivery           doordash.DeliveryInfo `json:"delivery"`
+		History            []StatusChange        `json:"history"`
+		CreatedAt          time.Time             `json:"created_at"`
+		UpdatedAt          time.Time             `json:"updated_at"`
+	}
+
+	// A delivery status along with when and where it was observed
+	StatusChange struct {
+		Status string    `json:"status"`
+		At     time.Time `json:"at"`
+		// Where the snapshot came from, e.g. "CreateDelivery" or
+		// "webhook:DASHER_CONFIRMED"
+		Source string `json:"source"`
+	}
+
+	// Filter for Find. Empty fields match every record.
+	Query struct {
+		ExternalStoreID string
+		Status          string
+		// Only records first seen within [From, To)
+		From time.Time
+		To   time.Time
+	}
+)
+
+func (q Query) matches(r *Record) bool {
+	switch {
+	case q.ExternalStoreID != "" && r.Delivery.PickupExternalStoreID != q.ExternalStoreID:
+		return false
+	case q.Status != "" && r.Delivery.DeliveryStatus != q.Status:
+		return false
+	case !q.From.IsZero() && r.CreatedAt.Before(q.From):
+		return false
+	case !q.To.IsZero() && !r.CreatedAt.Before(q.To):
+		return false
+	}
+	return true
+}
+
+// apply folds a snapshot into a record, creating it when r is nil
+func apply(r *Record, d *doordash.DeliveryInfo, source string, at time.Time) *Record {
+	if r == nil {
+		r = &Record{ExternalDeliveryID: d.ExternalDeliveryID, CreatedAt: at}
+	}
+	// Webhooks may arrive out of order; an older snapshot only adds history
+	if !at.Before(r.UpdatedAt) {
+		r.Delivery = *d
+		r.UpdatedAt = at
+	}
+	if at.Before(r.CreatedAt) {
+		r.CreatedAt = at
+	}
+
+	history := append(r.History, StatusChange{Status: d.DeliveryStatus, At: at, Source: source})
+	sort.SliceStable(history, func(i, j int) bool { return history[i].At.Before(history[j].At) })
+	r.History = history[:0]
+	for _, change := range history {
+		if n := len(r.History); n == 0 || r.History[n-1].Status != change.Status {
+			r.History = append(r.History, change)
+		}
+	}
+	return r
+}
+
+func copyRecord(r *Record) *Record {
+	c := *r
+	c.History = append([]StatusChange(nil), r.History...)
+	return &c
+}
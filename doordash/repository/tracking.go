@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// TrackingClient wraps a doordash.Client and records every delivery it
+// returns. Recording failures are returned alongside the delivery, which is
+// still valid since the API call itself succeeded.
+type TrackingClient struct {
+	Client     *doordash.Client
+	Repository DeliveryRepository
+	// Clock used to timestamp snapshots, defaults to time.Now
+	Now func() time.Time
+}
+
+func (t *TrackingClient) CreateDelivery(d *doordash.NewDelivery) (*doordash.DeliveryInfo, error) {
+	return t.record("CreateDelivery")(t.Client.CreateDelivery(d))
+}
+
+func (t *TrackingClient) GetDeliveryStatus(externalDeliveryID string) (*doordash.DeliveryInfo, error) {
+	return t.record("GetDeliveryStatus")(t.Client.GetDeliveryStatus(externalDeliveryID))
+}
+
+func (t *TrackingClient) UpdateDelivery(externalDeliveryID string, d *doordash.DeliveryUpdate) (*doordash.DeliveryInfo, error) {
+	return t.record("UpdateDelivery")(t.Client.UpdateDelivery(externalDeliveryID, d))
+}
+
+func (t *TrackingClient) CancelDelivery(externalDeliveryID string) (*doordash.DeliveryInfo, error) {
+	return t.record("CancelDelivery")(t.Client.CancelDelivery(externalDeliveryID))
+}
+
+// HandleEvent records a delivery webhook at the time DoorDash created it. It
+// can be passed directly to doordash.NewWebhookHandler.
+func (t *TrackingClient) HandleEvent(e *doordash.DeliveryEvent) error {
+	at := e.CreatedAt
+	if at.IsZero() {
+		at = t.now()
+	}
+	return t.Repository.Save(&e.DeliveryInfo, "webhook:"+e.EventName, at)
+}
+
+func (t *TrackingClient) record(source string) func(*doordash.DeliveryInfo, error) (*doordash.DeliveryInfo, error) {
+	return func(d *doordash.DeliveryInfo, err error) (*doordash.DeliveryInfo, error) {
+		if err != nil {
+			return nil, err
+		}
+		return d, t.Repository.Save(d, source, t.now())
+	}
+}
+
+func (t *TrackingClient) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
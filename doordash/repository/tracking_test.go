@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestTrackingClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Send response to be tested
+		rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "created", "pickup_external_store_id": "S-1"}`))
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	client := doordash.NewClient("token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	repo := NewMemory()
+	tracking := &TrackingClient{Client: client, Repository: repo, Now: func() time.Time { return t0 }}
+
+	if _, err := tracking.CreateDelivery(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	event := &doordash.DeliveryEvent{EventName: "DASHER_CONFIRMED", CreatedAt: t0.Add(time.Minute)}
+	event.ExternalDeliveryID = "D-1"
+	event.DeliveryStatus = "confirmed"
+	if err := tracking.HandleEvent(event); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	got, _ := repo.Get("D-1")
+	want := []StatusChange{
+		{Status: "created", At: t0, Source: "CreateDelivery"},
+		{Status: "confirmed", At: t0.Add(time.Minute), Source: "webhook:DASHER_CONFIRMED"},
+	}
+	if !reflect.DeepEqual(got.History, want) {
+		t.Errorf("expected history to be %v, got %v", want, got.History)
+	}
+}
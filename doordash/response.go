@@ -0,0 +1,107 @@
+// Response metadata
+package doordash
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metadata about the HTTP exchange behind an API call, captured with
+// WithResponseCapture. It is filled in for error responses too, so the
+// request ID can be quoted to DoorDash support.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Value of the X-Request-Id response header
+	RequestID string
+	// Rate-limit headers, nil if the response carried none
+	RateLimit *RateLimit
+	// Time from sending the request until the whole body was read
+	Latency time.Duration
+	// Number of times the call was sent, counting retries made by
+	// middleware calling next again. Retries inside a custom http.Client
+	// transport are not seen by the client and not counted.
+	Attempts int
+	// ID of the key that signed the request, if any
+	KeyID       string
+	Environment Environment
+	// Raw response body. Always set for middleware; only kept in captured
+	// responses with WithRawBody.
+	Body []byte
+}
+
+// Rate-limit state reported by the API. Fields not present in the response
+// are -1.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	// Seconds until the limit resets
+	Reset int
+}
+
+// Option for a single API call
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	ctx       context.Context
+	operation string
+	capture   *Response
+	rawBody   bool
+}
+
+// WithResponseCapture stores the metadata of the call's response in r
+func WithResponseCapture(r *Response) CallOption {
+	return func(cfg *callConfig) {
+		cfg.capture = r
+	}
+}
+
+// WithRawBody keeps the raw response body in the captured Response. It has
+// no effect without WithResponseCapture.
+func WithRawBody() CallOption {
+	return func(cfg *callConfig) {
+		cfg.rawBody = true
+	}
+}
+
+func newCallConfig(opts []CallOption) *callConfig {
+	cfg := &callConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func newResponse(req *http.Request, res *http.Response, body []byte, latency time.Duration, attempts int) *Response {
+	return &Response{
+		StatusCode:  res.StatusCode,
+		Header:      res.Header,
+		RequestID:   res.Header.Get("X-Request-Id"),
+		RateLimit:   parseRateLimit(res.Header),
+		Latency:     latency,
+		Attempts:    attempts,
+		KeyID:       SigningKeyID(req),
+		Environment: RequestEnvironment(req),
+		Body:        body,
+	}
+}
+
+func parseRateLimit(h http.Header) *RateLimit {
+	limit, okLimit := headerInt(h, "X-RateLimit-Limit")
+	remaining, okRemaining := headerInt(h, "X-RateLimit-Remaining")
+	reset, okReset := headerInt(h, "X-RateLimit-Reset")
+	if !okLimit && !okRemaining && !okReset {
+		return nil
+	}
+	return &RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	n, err := strconv.Atoi(h.Get(key))
+	if err != nil {
+		return -1, false
+	}
+	return n, true
+}
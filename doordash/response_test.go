@@ -0,0 +1,55 @@
+package doordash
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestResponseCapture(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "req-123")
+		rw.Header().Set("X-RateLimit-Limit", "100")
+		rw.Header().Set("X-RateLimit-Remaining", "99")
+		rw.WriteHeader(status)
+		// Send response to be tested
+		if status == http.StatusOK {
+			rw.Write(deliveryResponse)
+		} else {
+			rw.Write([]byte(`{"code":"not_found","message":"Delivery not found"}`))
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithEnvironment(Sandbox), WithHTTPClient(server.Client()))
+	c.BaseURL = url
+
+	var meta Response
+	if _, err := c.GetDeliveryStatus("D-12345", WithResponseCapture(&meta), WithRawBody()); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-123" || meta.Attempts != 1 || meta.Environment != Sandbox {
+		t.Errorf("unexpected response metadata %+v", meta)
+	}
+	if meta.RateLimit == nil || meta.RateLimit.Limit != 100 || meta.RateLimit.Remaining != 99 || meta.RateLimit.Reset != -1 {
+		t.Errorf("unexpected rate limit %+v", meta.RateLimit)
+	}
+	if string(meta.Body) != string(deliveryResponse) {
+		t.Errorf("expected raw body to be kept, got %q", meta.Body)
+	}
+
+	status = http.StatusNotFound
+	meta = Response{}
+	_, err := c.GetDeliveryStatus("D-12345", WithResponseCapture(&meta))
+	apiErr, ok := err.(*Error)
+	if !ok || apiErr.RequestID != "req-123" || apiErr.Code != "not_found" {
+		t.Errorf("expected API error with request ID, got %v", err)
+	}
+	if meta.StatusCode != http.StatusNotFound || meta.Body != nil {
+		t.Errorf("expected error response captured without body, got %+v", meta)
+	}
+}
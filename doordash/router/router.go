@@ -0,0 +1,287 @@
+// Package router picks the store a delivery is dispatched from. Candidate
+// stores, typically from ListStores, are ranked by availability and distance
+// to the dropoff and quoted in that order; the nearest store whose quote is
+// accepted wins, and every store passed over is explained.
+package router
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const earthRadiusKm = 6371.0
+
+// Why a store was passed over
+type RejectionKind string
+
+const (
+	// The store is not active
+	Inactive RejectionKind = "inactive"
+	// The store's operating or special hours say it is closed
+	Closed RejectionKind = "closed"
+	// The store is farther than Router.MaxDistanceKm from the dropoff
+	TooFar RejectionKind = "too_far"
+	// The quote request failed, e.g. because the dropoff is out of range
+	QuoteFailed RejectionKind = "quote_failed"
+	// The quote was refused by Router.Policy
+	PolicyRejected RejectionKind = "policy_rejected"
+)
+
+type Coordinates struct {
+	Lat float64
+	Lng float64
+}
+
+// Resolves addresses to coordinates
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (Coordinates, error)
+}
+
+// Adapter to use an ordinary function as a Geocoder
+type GeocoderFunc func(ctx context.Context, address string) (Coordinates, error)
+
+func (f GeocoderFunc) Geocode(ctx context.Context, address string) (Coordinates, error) {
+	return f(ctx, address)
+}
+
+type Router struct {
+	Client *doordash.Client
+	// Used to rank stores by distance. Without it, or for addresses it
+	// cannot resolve, stores keep their given order after the ranked ones.
+	Geocoder Geocoder
+	// Stores farther than this from the dropoff are not quoted; zero means
+	// no limit
+	MaxDistanceKm float64
+	// Number of stores quoted at once, defaults to 1. With more than one,
+	// each store is quoted under its own external delivery ID, the request's
+	// ID followed by "-" and the store ID, so the quotes do not replace each
+	// other; the winning quote must be accepted under that ID. Quotes of
+	// the other stores in the batch are returned in Result.Unused.
+	Parallel int
+	// Optional policy a quote must pass to be accepted
+	Policy doordash.QuotePolicy
+	// Clock, defaults to time.Now
+	Now func() time.Time
+
+	// Geocoded store addresses
+	stores sync.Map
+}
+
+// Store considered for a delivery
+type Candidate struct {
+	Store doordash.StoreInfo
+	// Distance to the dropoff, -1 if unknown
+	DistanceKm float64
+}
+
+type Rejection struct {
+	ExternalStoreID string        `json:"external_store_id"`
+	Kind            RejectionKind `json:"kind"`
+	Reason          string        `json:"reason"`
+	DistanceKm      float64       `json:"distance_km"`
+}
+
+type Result struct {
+	// Chosen store and its quote, nil if every store was rejected
+	Store      *doordash.StoreInfo
+	Quote      *doordash.DeliveryInfo
+	DistanceKm float64
+	// Stores passed over, in ranking order
+	Rejections []Rejection
+	// Quotes of farther stores in the winning batch when quoting in
+	// parallel. They are not accepted and simply expire.
+	Unused []*doordash.DeliveryInfo
+}
+
+// NoStoreError is returned when no candidate store could take the delivery
+type NoStoreError struct {
+	Rejections []Rejection
+}
+
+func (e *NoStoreError) Error() string {
+	reasons := make([]string, len(e.Rejections))
+	for i, r := range e.Rejections {
+		reasons[i] = fmt.Sprintf("%s: %s", r.ExternalStoreID, r.Reason)
+	}
+	return "router: no store can take the delivery (" + strings.Join(reasons, "; ") + ")"
+}
+
+// Route quotes q from the best of stores. The pickup fields of q are
+// filled in from each store; dropoff is geocoded from q.DropoffAddress when
+// nil. If no store can take the delivery, the result is returned along with
+// a *NoStoreError.
+func (r *Router) Route(ctx context.Context, q *doordash.NewQuote, dropoff *Coordinates, stores []doordash.StoreInfo) (*Result, error) {
+	res := &Result{}
+	candidates, rejections := r.Rank(ctx, q.DropoffAddress, dropoff, stores)
+	res.Rejections = rejections
+
+	parallel := r.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	// Quote in batches; within a batch the nearest accepted quote wins
+	for start := 0; start < len(candidates); start += parallel {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		end := start + parallel
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		batch := candidates[start:end]
+
+		quotes := make([]*doordash.DeliveryInfo, len(batch))
+		batchRejections := make([]*Rejection, len(batch))
+		done := make(chan struct{})
+		for i := range batch {
+			go func(i int) {
+				defer func() { done <- struct{}{} }()
+				quotes[i], batchRejections[i] = r.quote(ctx, q, &batch[i], parallel > 1)
+			}(i)
+		}
+		for range batch {
+			<-done
+		}
+
+		for i := range batch {
+			if batchRejections[i] != nil {
+				res.Rejections = append(res.Rejections, *batchRejections[i])
+				continue
+			}
+			store := batch[i].Store
+			res.Store, res.Quote, res.DistanceKm = &store, quotes[i], batch[i].DistanceKm
+			for _, unused := range quotes[i+1:] {
+				if unused != nil {
+					res.Unused = append(res.Unused, unused)
+				}
+			}
+			return res, nil
+		}
+	}
+
+	return res, &NoStoreError{Rejections: res.Rejections}
+}
+
+// Rank orders stores for quoting: available stores nearest first, then
+// available stores of unknown distance in their given order. Inactive,
+// closed and too distant stores are returned as rejections.
+func (r *Router) Rank(ctx context.Context, dropoffAddress string, dropoff *Coordinates, stores []doordash.StoreInfo) ([]Candidate, []Rejection) {
+	if dropoff == nil && r.Geocoder != nil && dropoffAddress != "" {
+		if c, err := r.Geocoder.Geocode(ctx, dropoffAddress); err == nil {
+			dropoff = &c
+		}
+	}
+
+	now := r.now()
+	var candidates []Candidate
+	var rejections []Rejection
+	for _, s := range stores {
+		c := Candidate{Store: s, DistanceKm: -1}
+		if dropoff != nil {
+			if coords, ok := r.storeCoordinates(ctx, &s); ok {
+				c.DistanceKm = distanceKm(*dropoff, coords)
+			}
+		}
+
+		switch open, reason := isOpen(&s, now); {
+		case s.Status != "" && s.Status != "active":
+			rejections = append(rejections, Rejection{s.ExternalStoreID, Inactive, "store is " + s.Status, c.DistanceKm})
+		case !open:
+			rejections = append(rejections, Rejection{s.ExternalStoreID, Closed, reason, c.DistanceKm})
+		case r.MaxDistanceKm > 0 && c.DistanceKm > r.MaxDistanceKm:
+			rejections = append(rejections, Rejection{s.ExternalStoreID, TooFar, fmt.Sprintf("%.1f km from dropoff, limit %.1f km", c.DistanceKm, r.MaxDistanceKm), c.DistanceKm})
+		default:
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].DistanceKm, candidates[j].DistanceKm
+		if a < 0 || b < 0 {
+			return b < 0 && a >= 0
+		}
+		return a < b
+	})
+	return candidates, rejections
+}
+
+func (r *Router) quote(ctx context.Context, base *doordash.NewQuote, c *Candidate, ownID bool) (*doordash.DeliveryInfo, *Rejection) {
+	q := *base
+	q.PickupAddress = c.Store.Address
+	q.PickupBusinessName = c.Store.Name
+	q.PickupPhoneNumber = c.Store.PhoneNumber
+	q.PickupExternalBusinessID = c.Store.ExternalBusinessID
+	q.PickupExternalStoreID = c.Store.ExternalStoreID
+	if ownID {
+		q.ExternalDeliveryID = base.ExternalDeliveryID + "-" + c.Store.ExternalStoreID
+	}
+
+	quote, err := r.Client.CreateDeliveryQuote(&q, doordash.WithContext(ctx))
+	if err != nil {
+		return nil, &Rejection{c.Store.ExternalStoreID, QuoteFailed, err.Error(), c.DistanceKm}
+	}
+	if r.Policy != nil {
+		decision := r.Policy.Evaluate(&doordash.QuoteContext{Request: &q, Quote: quote, Now: r.now()})
+		if !decision.Accept {
+			reasons := make([]string, len(decision.Reasons))
+			for i, reason := range decision.Reasons {
+				reasons[i] = reason.Message
+			}
+			return nil, &Rejection{c.Store.ExternalStoreID, PolicyRejected, strings.Join(reasons, "; "), c.DistanceKm}
+		}
+	}
+	return quote, nil
+}
+
+func (r *Router) storeCoordinates(ctx context.Context, s *doordash.StoreInfo) (Coordinates, bool) {
+	if r.Geocoder == nil || s.Address == "" {
+		return Coordinates{}, false
+	}
+	if c, ok := r.stores.Load(s.Address); ok {
+		return c.(Coordinates), true
+	}
+	c, err := r.Geocoder.Geocode(ctx, s.Address)
+	if err != nil {
+		return Coordinates{}, false
+	}
+	r.stores.Store(s.Address, c)
+	return c, true
+}
+
+func (r *Router) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// isOpen checks the store's hours at now. Stores whose hours cannot be read,
+// e.g. because of an unknown timezone, are assumed to be open.
+func isOpen(s *doordash.StoreInfo, now time.Time) (bool, string) {
+	open, err := s.OpenAt(now)
+	if err != nil || open {
+		return true, ""
+	}
+	local := now
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		local = now.In(loc)
+	}
+	return false, fmt.Sprintf("store is closed on %s at %s", local.Format("Monday 2006-01-02"), local.Format("15:04"))
+}
+
+// distanceKm is the great-circle distance between two points
+func distanceKm(a Coordinates, b Coordinates) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,169 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var coordinates = map[string]Coordinates{
+	"dropoff": {37.7793, -122.4193},
+	"near":    {37.7825, -122.4106}, // under 1 km
+	"mid":     {37.7599, -122.4148}, // about 2 km
+	"far":     {37.8044, -122.2712}, // Oakland, about 13 km
+}
+
+func newTestRouter(t *testing.T, outOfRange map[string]bool) (*Router, *[]string) {
+	var mu sync.Mutex
+	quoted := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		q := &doordash.NewQuote{}
+		json.NewDecoder(req.Body).Decode(q)
+		mu.Lock()
+		quoted = append(quoted, q.PickupExternalStoreID)
+		mu.Unlock()
+		// Send response to be tested
+		if outOfRange[q.PickupExternalStoreID] {
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code": "validation_error", "field_errors": [{"field": "dropoff_address", "error": "Allowed distance between addresses exceeded"}]}`))
+			return
+		}
+		json.NewEncoder(rw).Encode(&doordash.DeliveryInfo{ExternalDeliveryID: q.ExternalDeliveryID, Fee: 975, PickupExternalStoreID: q.PickupExternalStoreID})
+	}))
+	// Close the server when test finishes
+	t.Cleanup(server.Close)
+
+	url, _ := url.Parse(server.URL + "/")
+	c := doordash.NewClient("token", doordash.WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	return &Router{
+		Client: c,
+		Geocoder: GeocoderFunc(func(ctx context.Context, address string) (Coordinates, error) {
+			if c, ok := coordinates[address]; ok {
+				return c, nil
+			}
+			return Coordinates{}, errors.New("address not found")
+		}),
+		Now: func() time.Time { return time.Date(2023, 5, 1, 19, 0, 0, 0, time.UTC) }, // Monday noon in San Francisco
+	}, &quoted
+}
+
+func testStores() []doordash.StoreInfo {
+	return []doordash.StoreInfo{
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-far", Address: "far", Status: "active"},
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-unknown", Address: "somewhere", Status: "active"},
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-mid", Address: "mid", Status: "active"},
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-near", Address: "near", Status: "active"},
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-inactive", Address: "near", Status: "inactive"},
+		{ExternalBusinessID: "B-1", ExternalStoreID: "S-closed", Address: "near", Status: "active", Timezone: "America/Los_Angeles",
+			OperatingHours: []doordash.OperatingHours{{DayOfWeek: "monday", StartTime: "17:00", EndTime: "22:00"}}},
+	}
+}
+
+func TestRouteFailover(t *testing.T) {
+	r, quoted := newTestRouter(t, map[string]bool{"S-near": true})
+	r.MaxDistanceKm = 10
+
+	res, err := r.Route(context.Background(), &doordash.NewQuote{ExternalDeliveryID: "D-1", DropoffAddress: "dropoff"}, nil, testStores())
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if res.Store.ExternalStoreID != "S-mid" || res.Quote.ExternalDeliveryID != "D-1" || res.DistanceKm < 1 || res.DistanceKm > 3 {
+		t.Errorf("expected failover to S-mid, got %+v", res)
+	}
+	if strings.Join(*quoted, ",") != "S-near,S-mid" {
+		t.Errorf("expected stores quoted nearest first, got %v", *quoted)
+	}
+
+	kinds := map[string]RejectionKind{}
+	for _, rej := range res.Rejections {
+		kinds[rej.ExternalStoreID] = rej.Kind
+	}
+	want := map[string]RejectionKind{"S-far": TooFar, "S-inactive": Inactive, "S-closed": Closed, "S-near": QuoteFailed}
+	for id, kind := range want {
+		if kinds[id] != kind {
+			t.Errorf("%s: expected %s rejection, got %q", id, kind, kinds[id])
+		}
+	}
+	if len(res.Rejections) != len(want) {
+		t.Errorf("unexpected rejections %+v", res.Rejections)
+	}
+}
+
+func TestRouteParallel(t *testing.T) {
+	r, quoted := newTestRouter(t, map[string]bool{"S-near": true, "S-mid": true, "S-far": true, "S-unknown": true})
+	r.Parallel = 3
+
+	res, err := r.Route(context.Background(), &doordash.NewQuote{ExternalDeliveryID: "D-1"}, &Coordinates{37.7793, -122.4193}, testStores())
+	var noStore *NoStoreError
+	if !errors.As(err, &noStore) || res.Store != nil || len(noStore.Rejections) != 6 {
+		t.Fatalf("expected every store to be rejected, got %+v, %v", res, err)
+	}
+	if len(*quoted) != 4 {
+		t.Errorf("expected 4 stores quoted, got %v", *quoted)
+	}
+
+	r2, _ := newTestRouter(t, nil)
+	r2.Parallel, r2.Policy = 2, doordash.MaxFee{Default: 1000}
+	res, err = r2.Route(context.Background(), &doordash.NewQuote{ExternalDeliveryID: "D-1"}, &Coordinates{37.7793, -122.4193}, testStores())
+	if err != nil || res.Store.ExternalStoreID != "S-near" || res.Quote.ExternalDeliveryID != "D-1-S-near" {
+		t.Errorf("expected nearest store quoted under its own ID, got %+v, %v", res, err)
+	}
+	if len(res.Unused) != 1 || res.Unused[0].ExternalDeliveryID != "D-1-S-mid" {
+		t.Errorf("expected the losing quote to be returned, got %+v", res.Unused)
+	}
+}
+
+func TestRankOvernightHours(t *testing.T) {
+	r, _ := newTestRouter(t, nil)
+	// Tuesday 01:00 in San Francisco
+	r.Now = func() time.Time { return time.Date(2023, 5, 2, 8, 0, 0, 0, time.UTC) }
+
+	stores := []doordash.StoreInfo{
+		{ExternalStoreID: "S-late", Status: "active", Timezone: "America/Los_Angeles",
+			OperatingHours: []doordash.OperatingHours{{DayOfWeek: "monday", StartTime: "18:00", EndTime: "02:00"}}},
+		{ExternalStoreID: "S-early", Status: "active", Timezone: "America/Los_Angeles",
+			OperatingHours: []doordash.OperatingHours{{DayOfWeek: "monday", StartTime: "18:00", EndTime: "00:30"}}},
+	}
+	candidates, rejections := r.Rank(context.Background(), "", nil, stores)
+	if len(candidates) != 1 || candidates[0].Store.ExternalStoreID != "S-late" {
+		t.Errorf("expected store open past midnight to be a candidate, got %+v", candidates)
+	}
+	if len(rejections) != 1 || rejections[0].Kind != Closed || rejections[0].Reason != "store is closed on Tuesday 2023-05-02 at 01:00" {
+		t.Errorf("unexpected rejections %+v", rejections)
+	}
+}
+
+func TestRouteDeadline(t *testing.T) {
+	r, _ := newTestRouter(t, nil)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Quote slower than the caller is willing to wait
+		select {
+		case <-release:
+			return
+		case <-time.After(time.Second):
+		}
+		json.NewEncoder(rw).Encode(&doordash.DeliveryInfo{ExternalDeliveryID: "D-1", Fee: 975})
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+	defer close(release)
+	r.Client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	res, err := r.Route(ctx, &doordash.NewQuote{ExternalDeliveryID: "D-1"}, &Coordinates{37.7793, -122.4193}, testStores())
+	if !errors.Is(err, context.DeadlineExceeded) || res.Store != nil {
+		t.Errorf("expected the quote in flight to be abandoned at the deadline, got %+v, %v", res, err)
+	}
+}
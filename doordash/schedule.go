@@ -0,0 +1,224 @@
+// Timezone-aware scheduling of deliveries and quotes
+package doordash
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout of local wall-clock times in a ScheduleSpec
+const LocalTimeLayout = "2006-01-02T15:04"
+
+// Limits applied when resolving a ScheduleSpec. The defaults are conservative
+// and can be tightened or relaxed per spec.
+type ScheduleLimits struct {
+	// Minimum and maximum time between now and the earliest scheduled time
+	MinLeadTime time.Duration
+	MaxLeadTime time.Duration
+	// Minimum and maximum width of pickup and dropoff windows. Zero disables
+	// the check.
+	MinWindow time.Duration
+	MaxWindow time.Duration
+}
+
+var DefaultScheduleLimits = ScheduleLimits{
+	MinLeadTime: 0,
+	MaxLeadTime: 30 * 24 * time.Hour,
+	MinWindow:   10 * time.Minute,
+	MaxWindow:   0,
+}
+
+// A window of local wall-clock times in LocalTimeLayout
+type LocalWindow struct {
+	Start string
+	End   string
+}
+
+// Object describing when a delivery should happen in terms of the pickup
+// store's local time. Set exactly one of ASAP, PickupAt, DropoffBy or the
+// windows; PickupWindow and DropoffWindow may be combined.
+type ScheduleSpec struct {
+	ASAP          bool
+	PickupAt      string
+	DropoffBy     string
+	PickupWindow  *LocalWindow
+	DropoffWindow *LocalWindow
+	// Limits to validate against, DefaultScheduleLimits when nil
+	Limits *ScheduleLimits
+}
+
+// Absolute times resolved from a ScheduleSpec, in the store's location so
+// they serialize to RFC3339 with the store's offset
+type Schedule struct {
+	PickupTime    time.Time
+	DropoffTime   time.Time
+	PickupWindow  TimeWindow
+	DropoffWindow TimeWindow
+}
+
+// Resolve validates the spec and converts its local times into absolute times
+// in loc. now is used to check lead time limits.
+func (s ScheduleSpec) Resolve(loc *time.Location, now time.Time) (*Schedule, error) {
+	limits := DefaultScheduleLimits
+	if s.Limits != nil {
+		limits = *s.Limits
+	}
+
+	hasWindow := s.PickupWindow != nil || s.DropoffWindow != nil
+	set := 0
+	for _, ok := range []bool{s.ASAP, s.PickupAt != "", s.DropoffBy != "", hasWindow} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("schedule must set exactly one of ASAP, PickupAt, DropoffBy or windows")
+	}
+
+	sched := &Schedule{}
+	if s.ASAP {
+		return sched, nil
+	}
+
+	var err error
+	var earliest time.Time
+	switch {
+	case s.PickupAt != "":
+		if sched.PickupTime, err = parseLocalTime("PickupAt", s.PickupAt, loc); err != nil {
+			return nil, err
+		}
+		earliest = sched.PickupTime
+	case s.DropoffBy != "":
+		if sched.DropoffTime, err = parseLocalTime("DropoffBy", s.DropoffBy, loc); err != nil {
+			return nil, err
+		}
+		earliest = sched.DropoffTime
+	default:
+		if s.PickupWindow != nil {
+			if sched.PickupWindow, err = resolveWindow("PickupWindow", s.PickupWindow, loc, limits); err != nil {
+				return nil, err
+			}
+			earliest = sched.PickupWindow.StartTime
+		}
+		if s.DropoffWindow != nil {
+			if sched.DropoffWindow, err = resolveWindow("DropoffWindow", s.DropoffWindow, loc, limits); err != nil {
+				return nil, err
+			}
+			if s.PickupWindow != nil && sched.DropoffWindow.EndTime.Before(sched.PickupWindow.StartTime) {
+				return nil, fmt.Errorf("DropoffWindow ends before PickupWindow starts")
+			}
+			if earliest.IsZero() {
+				earliest = sched.DropoffWindow.StartTime
+			}
+		}
+	}
+
+	lead := earliest.Sub(now)
+	if lead < limits.MinLeadTime {
+		return nil, fmt.Errorf("schedule starts %v from now, minimum lead time is %v", lead.Round(time.Second), limits.MinLeadTime)
+	}
+	if limits.MaxLeadTime > 0 && lead > limits.MaxLeadTime {
+		return nil, fmt.Errorf("schedule starts %v from now, maximum lead time is %v", lead.Round(time.Second), limits.MaxLeadTime)
+	}
+
+	return sched, nil
+}
+
+// ApplyToDelivery sets the delivery's times, refusing to overwrite times that
+// were already set on it
+func (s *Schedule) ApplyToDelivery(d *NewDelivery) error {
+	if !d.PickupTime.IsZero() || !d.DropoffTime.IsZero() || d.PickupWindow != (TimeWindow{}) || d.DropoffWindow != (TimeWindow{}) {
+		return fmt.Errorf("delivery %s already has pickup or dropoff times set", d.ExternalDeliveryID)
+	}
+	d.PickupTime, d.DropoffTime = s.PickupTime, s.DropoffTime
+	d.PickupWindow, d.DropoffWindow = s.PickupWindow, s.DropoffWindow
+	return nil
+}
+
+// ApplyToQuote sets the quote's times, refusing to overwrite times that were
+// already set on it
+func (s *Schedule) ApplyToQuote(q *NewQuote) error {
+	if !q.PickupTime.IsZero() || !q.DropoffTime.IsZero() || q.PickupWindow != (TimeWindow{}) || q.DropoffWindow != (TimeWindow{}) {
+		return fmt.Errorf("quote %s already has pickup or dropoff times set", q.ExternalDeliveryID)
+	}
+	q.PickupTime, q.DropoffTime = s.PickupTime, s.DropoffTime
+	q.PickupWindow, q.DropoffWindow = s.PickupWindow, s.DropoffWindow
+	return nil
+}
+
+// ResolveSchedule resolves a spec in the timezone of the given store
+func (c *Client) ResolveSchedule(spec ScheduleSpec, externalBusinessID string, externalStoreID string) (*Schedule, error) {
+	store, err := c.GetStore(externalBusinessID, externalStoreID)
+	if err != nil {
+		return nil, err
+	}
+	if store.Timezone == "" {
+		return nil, fmt.Errorf("store %s has no timezone", externalStoreID)
+	}
+	loc, err := time.LoadLocation(store.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("store %s has invalid timezone %q: %v", externalStoreID, store.Timezone, err)
+	}
+	return spec.Resolve(loc, time.Now())
+}
+
+// CreateScheduledDelivery resolves the spec in the timezone of the delivery's
+// pickup store and creates the delivery
+func (c *Client) CreateScheduledDelivery(d *NewDelivery, spec ScheduleSpec) (*DeliveryInfo, error) {
+	sched, err := c.ResolveSchedule(spec, d.PickupExternalBusinessID, d.PickupExternalStoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.ApplyToDelivery(d); err != nil {
+		return nil, err
+	}
+	return c.CreateDelivery(d)
+}
+
+// CreateScheduledDeliveryQuote resolves the spec in the timezone of the
+// quote's pickup store and requests the quote
+func (c *Client) CreateScheduledDeliveryQuote(q *NewQuote, spec ScheduleSpec) (*DeliveryInfo, error) {
+	sched, err := c.ResolveSchedule(spec, q.PickupExternalBusinessID, q.PickupExternalStoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.ApplyToQuote(q); err != nil {
+		return nil, err
+	}
+	return c.CreateDeliveryQuote(q)
+}
+
+func parseLocalTime(name string, value string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(LocalTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: invalid local time %q, expected %s", name, value, LocalTimeLayout)
+	}
+	// Times skipped by a daylight saving change are normalized to another hour
+	if t.Format(LocalTimeLayout) != value {
+		return time.Time{}, fmt.Errorf("%s: %s does not exist in %s", name, value, loc)
+	}
+	return t, nil
+}
+
+func resolveWindow(name string, w *LocalWindow, loc *time.Location, limits ScheduleLimits) (TimeWindow, error) {
+	start, err := parseLocalTime(name, w.Start, loc)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+	end, err := parseLocalTime(name, w.End, loc)
+	if err != nil {
+		return TimeWindow{}, err
+	}
+
+	width := end.Sub(start)
+	if width <= 0 {
+		return TimeWindow{}, fmt.Errorf("%s: end %s must be after start %s", name, w.End, w.Start)
+	}
+	if limits.MinWindow > 0 && width < limits.MinWindow {
+		return TimeWindow{}, fmt.Errorf("%s: width %v is below the minimum of %v", name, width, limits.MinWindow)
+	}
+	if limits.MaxWindow > 0 && width > limits.MaxWindow {
+		return TimeWindow{}, fmt.Errorf("%s: width %v exceeds the maximum of %v", name, width, limits.MaxWindow)
+	}
+	return TimeWindow{StartTime: start, EndTime: end}, nil
+}
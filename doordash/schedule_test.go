@@ -0,0 +1,148 @@
+package doordash
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleSpecResolve(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	now := time.Date(2022, 4, 25, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		spec    ScheduleSpec
+		wantErr bool
+	}{
+		{name: "asap", spec: ScheduleSpec{ASAP: true}},
+		{name: "pickup at", spec: ScheduleSpec{PickupAt: "2022-04-25T12:00"}},
+		{name: "dropoff by", spec: ScheduleSpec{DropoffBy: "2022-04-25T13:00"}},
+		{name: "windows", spec: ScheduleSpec{
+			PickupWindow:  &LocalWindow{Start: "2022-04-25T12:00", End: "2022-04-25T12:30"},
+			DropoffWindow: &LocalWindow{Start: "2022-04-25T12:30", End: "2022-04-25T13:30"},
+		}},
+		{name: "nothing set", spec: ScheduleSpec{}, wantErr: true},
+		{name: "asap and pickup at", spec: ScheduleSpec{ASAP: true, PickupAt: "2022-04-25T12:00"}, wantErr: true},
+		{name: "pickup at and dropoff by", spec: ScheduleSpec{PickupAt: "2022-04-25T12:00", DropoffBy: "2022-04-25T13:00"}, wantErr: true},
+		{name: "pickup at and window", spec: ScheduleSpec{
+			PickupAt:      "2022-04-25T12:00",
+			DropoffWindow: &LocalWindow{Start: "2022-04-25T12:30", End: "2022-04-25T13:30"},
+		}, wantErr: true},
+		{name: "in the past", spec: ScheduleSpec{PickupAt: "2022-04-25T08:00"}, wantErr: true},
+		{name: "beyond max lead time", spec: ScheduleSpec{PickupAt: "2022-06-25T08:00"}, wantErr: true},
+		{name: "below min lead time", spec: ScheduleSpec{
+			PickupAt: "2022-04-25T09:10",
+			Limits:   &ScheduleLimits{MinLeadTime: time.Hour},
+		}, wantErr: true},
+		{name: "malformed time", spec: ScheduleSpec{PickupAt: "2022-04-25 12:00"}, wantErr: true},
+		{name: "window too narrow", spec: ScheduleSpec{
+			PickupWindow: &LocalWindow{Start: "2022-04-25T12:00", End: "2022-04-25T12:05"},
+		}, wantErr: true},
+		{name: "window too wide", spec: ScheduleSpec{
+			PickupWindow: &LocalWindow{Start: "2022-04-25T12:00", End: "2022-04-25T18:00"},
+			Limits:       &ScheduleLimits{MaxLeadTime: time.Hour * 24, MaxWindow: time.Hour},
+		}, wantErr: true},
+		{name: "window reversed", spec: ScheduleSpec{
+			PickupWindow: &LocalWindow{Start: "2022-04-25T12:30", End: "2022-04-25T12:00"},
+		}, wantErr: true},
+		{name: "dropoff before pickup", spec: ScheduleSpec{
+			PickupWindow:  &LocalWindow{Start: "2022-04-25T14:00", End: "2022-04-25T14:30"},
+			DropoffWindow: &LocalWindow{Start: "2022-04-25T12:30", End: "2022-04-25T13:30"},
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.spec.Resolve(loc, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	// Clocks spring forward at 02:00 in Los Angeles on this date
+	spring := time.Date(2023, 3, 11, 9, 0, 0, 0, loc)
+	if _, err := (ScheduleSpec{PickupAt: "2023-03-12T02:30"}).Resolve(loc, spring); err == nil {
+		t.Error("expected error for a time skipped by daylight saving, got nil")
+	}
+}
+
+func TestScheduleEncoding(t *testing.T) {
+	data, _ := json.Marshal(&NewQuote{ExternalDeliveryID: "D-12345", DropoffWindow: TimeWindow{
+		StartTime: time.Date(2022, 4, 25, 12, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2022, 4, 25, 13, 0, 0, 0, time.UTC),
+	}})
+	body := map[string]interface{}{}
+	json.Unmarshal(data, &body)
+	for _, field := range []string{"pickup_time", "dropoff_time", "pickup_window"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("expected unset %s to be left out, got %s", field, data)
+		}
+	}
+	if window, ok := body["dropoff_window"].(map[string]interface{}); !ok || window["start_time"] != "2022-04-25T12:00:00Z" {
+		t.Errorf("expected dropoff_window to be sent, got %s", data)
+	}
+	if body["external_delivery_id"] != "D-12345" || body["tip"] != 0.0 {
+		t.Errorf("expected other fields to be kept, got %s", data)
+	}
+
+	// Decoding is unchanged
+	q := &NewQuote{}
+	if err := json.Unmarshal(data, q); err != nil || q.DropoffWindow.EndTime.Hour() != 13 || !q.PickupTime.IsZero() {
+		t.Errorf("unexpected decoded quote %+v, error %v", q, err)
+	}
+}
+
+func TestCreateScheduledDelivery(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Send response to be tested
+		switch req.URL.Path {
+		case "/developer/v1/businesses/B-12345/stores/S-12345":
+			rw.Write(storeResponse)
+		case "/drive/v2/deliveries":
+			json.NewDecoder(req.Body).Decode(&body)
+			rw.Write(deliveryResponse)
+		default:
+			t.Errorf("unexpected request URL %s", req.URL)
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	pickupAt := time.Now().In(loc).Add(48 * time.Hour).Format(LocalTimeLayout)
+
+	payload := &NewDelivery{
+		ExternalDeliveryID:       "D-12345",
+		PickupExternalBusinessID: "B-12345",
+		PickupExternalStoreID:    "S-12345",
+	}
+
+	url, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
+	if _, err := client.CreateScheduledDelivery(payload, ScheduleSpec{PickupAt: pickupAt}); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	want, _ := time.ParseInLocation(LocalTimeLayout, pickupAt, loc)
+	if got := body["pickup_time"]; got != want.Format(time.RFC3339) {
+		t.Errorf("expected pickup_time %s, got %v", want.Format(time.RFC3339), got)
+	}
+	for _, field := range []string{"dropoff_time", "pickup_window", "dropoff_window"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("expected unset %s to be left out, got %v", field, body[field])
+		}
+	}
+	if got, _ := body["pickup_time"].(string); !strings.HasSuffix(got, "-07:00") && !strings.HasSuffix(got, "-08:00") {
+		t.Errorf("expected pickup_time in the store's offset, got %s", got)
+	}
+
+	// Times set directly on the delivery conflict with the schedule
+	if _, err := client.CreateScheduledDelivery(payload, ScheduleSpec{ASAP: true}); err == nil {
+		t.Error("expected error for a delivery with times already set, got nil")
+	}
+}
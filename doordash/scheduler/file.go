@@ -0,0 +1,45 @@
+package scheduler
+
+import "github.com/alext251/doordash-go-sdk/doordash/internal/jsonfile"
+
+// JobStore persisted to a JSON file so scheduled jobs survive restarts. The
+// whole file is rewritten atomically on every change.
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// OpenFileStore loads the jobs stored at path, starting empty if the file
+// does not exist yet
+func OpenFileStore(path string) (*FileStore, error) {
+	f := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+	var jobs []*Job
+	if err := jsonfile.Load(path, &jobs); err != nil {
+		return nil, err
+	}
+	for _, j := range jobs {
+		f.jobs[j.ID] = j
+	}
+	return f, nil
+}
+
+func (f *FileStore) Put(job *Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous, existed := f.jobs[job.ID]
+	j := *job
+	f.jobs[job.ID] = &j
+
+	jobs := make([]*Job, 0, len(f.jobs))
+	for _, j := range f.jobs {
+		jobs = append(jobs, j)
+	}
+	return jsonfile.Commit(f.path, jobs, func() {
+		if existed {
+			f.jobs[job.ID] = previous
+		} else {
+			delete(f.jobs, job.ID)
+		}
+	})
+}
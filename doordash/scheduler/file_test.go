@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	s := &Scheduler{Store: store}
+	s.Schedule(&Job{ID: "J-2", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-2"}, DispatchAt: t0.Add(1)})
+	s.Schedule(&Job{ID: "J-1", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-1"}, DispatchAt: t0})
+	s.Schedule(&Job{ID: "J-3", DispatchAt: t0})
+	done, _ := store.Get("J-3")
+	done.Status = StatusAccepted
+	store.Put(done)
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	pending, err := reopened.Pending()
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	var got []string
+	for _, j := range pending {
+		got = append(got, j.ID)
+	}
+	if want := []string{"J-1", "J-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected pending jobs %v, got %v", want, got)
+	}
+}
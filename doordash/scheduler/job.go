@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	StatusPending  JobStatus = "pending"
+	StatusAccepted JobStatus = "accepted"
+	StatusRejected JobStatus = "rejected"
+	StatusFailed   JobStatus = "failed"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type (
+	JobStatus string
+
+	// A delivery to be dispatched at a later time
+	Job struct {
+		ID         string               `json:"id"`
+		Delivery   doordash.NewDelivery `json:"delivery"`
+		DispatchAt time.Time            `json:"dispatch_at"`
+		// Highest fee, in cents, at which the quote is accepted. Zero means
+		// any fee is accepted.
+		MaxFee int `json:"max_fee"`
+
+		Status    JobStatus              `json:"status"`
+		Attempts  int                    `json:"attempts"`
+		Quote     *doordash.DeliveryInfo `json:"quote,omitempty"`
+		Error     string                 `json:"error,omitempty"`
+		UpdatedAt time.Time              `json:"updated_at"`
+	}
+
+	// Persistence for scheduled jobs
+	JobStore interface {
+		// Put inserts or replaces a job
+		Put(job *Job) error
+		// Get returns a job or ErrJobNotFound
+		Get(id string) (*Job, error)
+		// Pending returns the jobs still waiting to be dispatched, earliest
+		// dispatch time first
+		Pending() ([]*Job, error)
+	}
+
+	// In-memory JobStore, safe for concurrent use
+	MemoryStore struct {
+		mu   sync.RWMutex
+		jobs map[string]*Job
+	}
+)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: map[string]*Job{}}
+}
+
+func (m *MemoryStore) Put(job *Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := *job
+	m.jobs[job.ID] = &j
+	return nil
+}
+
+func (m *MemoryStore) Get(id string) (*Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	c := *j
+	return &c, nil
+}
+
+func (m *MemoryStore) Pending() ([]*Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var pending []*Job
+	for _, j := range m.jobs {
+		if j.Status == StatusPending {
+			c := *j
+			pending = append(pending, &c)
+		}
+	}
+	sort.Slice(pending, func(i, k int) bool {
+		if pending[i].DispatchAt.Equal(pending[k].DispatchAt) {
+			return pending[i].ID < pending[k].ID
+		}
+		return pending[i].DispatchAt.Before(pending[k].DispatchAt)
+	})
+	return pending, nil
+}
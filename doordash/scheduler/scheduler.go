@@ -0,0 +1,193 @@
+// Package scheduler dispatches deliveries at a future time. Jobs are quoted a
+// configurable lead time before their dispatch time and the quote is accepted
+// when the fee is within the job's budget and the scheduler's quote policy
+// allows it.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	defaultPollInterval = 30 * time.Second
+	defaultMaxAttempts  = 3
+)
+
+type Scheduler struct {
+	Client *doordash.Client
+	Store  JobStore
+	// How long before a job's dispatch time it is quoted and accepted
+	LeadTime time.Duration
+	// How often Run checks for due jobs, defaults to 30 seconds
+	PollInterval time.Duration
+	// Optional policy evaluated after the job's MaxFee budget
+	Policy doordash.QuotePolicy
+	// Number of failed quote or accept calls before a job is marked failed,
+	// defaults to 3
+	MaxAttempts int
+
+	// Called when a job's quote is accepted
+	OnAccepted func(job *Job)
+	// Called when a job's quote is over budget or rejected by Policy; the
+	// quote is not accepted
+	OnRejected func(job *Job)
+	// Called for every failed attempt, with job.Status set to StatusFailed
+	// once the job has run out of attempts
+	OnError func(job *Job, err error)
+
+	// Clock, defaults to time.Now
+	Now func() time.Time
+}
+
+// Schedule stores a new pending job
+func (s *Scheduler) Schedule(job *Job) error {
+	if job.ID == "" {
+		return fmt.Errorf("job ID is required")
+	}
+	if job.DispatchAt.IsZero() {
+		return fmt.Errorf("job %s has no dispatch time", job.ID)
+	}
+	if _, err := s.Store.Get(job.ID); err == nil {
+		return fmt.Errorf("job %s already exists", job.ID)
+	} else if err != ErrJobNotFound {
+		return err
+	}
+
+	job.Status = StatusPending
+	job.Attempts = 0
+	job.UpdatedAt = s.now()
+	return s.Store.Put(job)
+}
+
+// Run processes due jobs every PollInterval until ctx is cancelled. Jobs
+// are read from the store on every pass, so pending jobs persisted before a
+// restart are picked up again.
+func (s *Scheduler) Run(ctx context.Context) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick processes every pending job that is due within the lead time. It only
+// returns an error if the store cannot be read or written; API failures are
+// recorded on the job and reported through OnError.
+func (s *Scheduler) Tick() error {
+	jobs, err := s.Store.Pending()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for _, job := range jobs {
+		if job.DispatchAt.Add(-s.LeadTime).After(now) {
+			// Pending jobs are sorted, so the rest are not due either
+			break
+		}
+		if err := s.dispatch(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) dispatch(job *Job) error {
+	quote := doordash.NewQuote(job.Delivery)
+	info, err := s.Client.CreateDeliveryQuote(&quote)
+	if err != nil {
+		return s.fail(job, err)
+	}
+	job.Quote = info
+
+	reason := ""
+	if job.MaxFee > 0 && info.Fee > job.MaxFee {
+		reason = fmt.Sprintf("fee %d exceeds budget of %d", info.Fee, job.MaxFee)
+	} else if s.Policy != nil {
+		if decision := s.Policy.Evaluate(&doordash.QuoteContext{Request: &quote, Quote: info, Now: s.now()}); !decision.Accept {
+			reason = "rejected by policy"
+			for i, r := range decision.Reasons {
+				if i == 0 {
+					reason = r.Message
+				} else {
+					reason += "; " + r.Message
+				}
+			}
+		}
+	}
+	if reason != "" {
+		job.Status = StatusRejected
+		job.Error = reason
+		if err := s.save(job); err != nil {
+			return err
+		}
+		if s.OnRejected != nil {
+			s.OnRejected(job)
+		}
+		return nil
+	}
+
+	info, err = s.Client.AcceptDeliveryQuote(job.Delivery.ExternalDeliveryID)
+	if err != nil {
+		return s.fail(job, err)
+	}
+
+	job.Quote = info
+	job.Status = StatusAccepted
+	job.Error = ""
+	if err := s.save(job); err != nil {
+		return err
+	}
+	if s.OnAccepted != nil {
+		s.OnAccepted(job)
+	}
+	return nil
+}
+
+func (s *Scheduler) fail(job *Job, apiErr error) error {
+	maxAttempts := s.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	job.Attempts++
+	job.Error = apiErr.Error()
+	if job.Attempts >= maxAttempts {
+		job.Status = StatusFailed
+	}
+	if err := s.save(job); err != nil {
+		return err
+	}
+	if s.OnError != nil {
+		s.OnError(job, apiErr)
+	}
+	return nil
+}
+
+func (s *Scheduler) save(job *Job) error {
+	job.UpdatedAt = s.now()
+	return s.Store.Put(job)
+}
+
+func (s *Scheduler) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
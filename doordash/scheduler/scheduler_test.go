@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var t0 = time.Date(2022, 4, 25, 17, 0, 0, 0, time.UTC)
+
+func newTestServer(fee string, accepted *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/drive/v2/quotes":
+			rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "quote", "fee": ` + fee + `}`))
+		case strings.HasSuffix(req.URL.Path, "/accept"):
+			*accepted = append(*accepted, req.URL.Path)
+			rw.Write([]byte(`{"external_delivery_id": "D-1", "delivery_status": "created", "fee": ` + fee + `}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestScheduler(server *httptest.Server, now *time.Time) *Scheduler {
+	client := doordash.NewClient("token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return &Scheduler{
+		Client:   client,
+		Store:    NewMemoryStore(),
+		LeadTime: 15 * time.Minute,
+		Now:      func() time.Time { return *now },
+	}
+}
+
+func TestSchedulerAccepts(t *testing.T) {
+	var accepted []string
+	server := newTestServer("975", &accepted)
+	defer server.Close()
+
+	now := t0
+	s := newTestScheduler(server, &now)
+	var done []*Job
+	s.OnAccepted = func(job *Job) { done = append(done, job) }
+
+	job := &Job{ID: "J-1", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-1"}, DispatchAt: t0.Add(time.Hour), MaxFee: 1000}
+	if err := s.Schedule(job); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	// Not yet within the lead time
+	s.Tick()
+	if len(accepted) != 0 {
+		t.Fatalf("expected no accepted quotes yet, got %v", accepted)
+	}
+
+	now = t0.Add(46 * time.Minute)
+	s.Tick()
+	if len(accepted) != 1 || accepted[0] != "/drive/v2/quotes/D-1/accept" {
+		t.Fatalf("expected quote D-1 to be accepted, got %v", accepted)
+	}
+	if len(done) != 1 || done[0].Status != StatusAccepted || done[0].Quote.Fee != 975 {
+		t.Errorf("expected OnAccepted with the accepted job, got %v", done)
+	}
+
+	// Accepted jobs are not dispatched again
+	s.Tick()
+	if len(accepted) != 1 {
+		t.Errorf("expected a single accept call, got %v", accepted)
+	}
+}
+
+func TestSchedulerRejectsOverBudget(t *testing.T) {
+	var accepted []string
+	server := newTestServer("1500", &accepted)
+	defer server.Close()
+
+	now := t0
+	s := newTestScheduler(server, &now)
+	var rejected *Job
+	s.OnRejected = func(job *Job) { rejected = job }
+
+	s.Schedule(&Job{ID: "J-1", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-1"}, DispatchAt: t0, MaxFee: 1000})
+	s.Tick()
+
+	if len(accepted) != 0 {
+		t.Errorf("expected over-budget quote not to be accepted, got %v", accepted)
+	}
+	if rejected == nil || rejected.Status != StatusRejected {
+		t.Errorf("expected OnRejected with a rejected job, got %v", rejected)
+	}
+}
+
+func TestSchedulerRetriesThenFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	now := t0
+	s := newTestScheduler(server, &now)
+	s.MaxAttempts = 2
+	var errs int
+	s.OnError = func(job *Job, err error) { errs++ }
+
+	s.Schedule(&Job{ID: "J-1", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-1"}, DispatchAt: t0})
+	s.Tick()
+	if job, _ := s.Store.Get("J-1"); job.Status != StatusPending || job.Attempts != 1 {
+		t.Errorf("expected job to stay pending after one failure, got %s after %d attempts", job.Status, job.Attempts)
+	}
+
+	s.Tick()
+	if job, _ := s.Store.Get("J-1"); job.Status != StatusFailed {
+		t.Errorf("expected job to fail after max attempts, got %s", job.Status)
+	}
+	if errs != 2 {
+		t.Errorf("expected OnError twice, got %d", errs)
+	}
+}
+
+func TestScheduleValidation(t *testing.T) {
+	s := &Scheduler{Store: NewMemoryStore()}
+	if err := s.Schedule(&Job{DispatchAt: t0}); err == nil {
+		t.Error("expected error for a job without ID, got nil")
+	}
+	if err := s.Schedule(&Job{ID: "J-1"}); err == nil {
+		t.Error("expected error for a job without dispatch time, got nil")
+	}
+	s.Schedule(&Job{ID: "J-1", DispatchAt: t0})
+	if err := s.Schedule(&Job{ID: "J-1", DispatchAt: t0}); err == nil {
+		t.Error("expected error for a duplicate job, got nil")
+	}
+}
+
+func TestSchedulerPolicy(t *testing.T) {
+	var accepted []string
+	server := newTestServer("975", &accepted)
+	defer server.Close()
+
+	now := t0
+	s := newTestScheduler(server, &now)
+	s.Policy = doordash.MaxFeeRatio(0.1)
+	var rejected *Job
+	s.OnRejected = func(job *Job) { rejected = job }
+
+	s.Schedule(&Job{ID: "J-1", Delivery: doordash.NewDelivery{ExternalDeliveryID: "D-1", OrderValue: 1999}, DispatchAt: t0})
+	s.Tick()
+
+	if len(accepted) != 0 {
+		t.Errorf("expected quote rejected by policy not to be accepted, got %v", accepted)
+	}
+	if rejected == nil || rejected.Error == "" {
+		t.Errorf("expected OnRejected with the policy's reason, got %v", rejected)
+	}
+}
@@ -0,0 +1,231 @@
+// Address serviceability checks
+package doordash
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default time serviceability outcomes are cached
+const DefaultServiceabilityTTL = 10 * time.Minute
+
+// Outcome of a serviceability check
+type ServiceabilityStatus string
+
+const (
+	Serviceable    ServiceabilityStatus = "serviceable"
+	OutOfRange     ServiceabilityStatus = "out_of_range"
+	AddressInvalid ServiceabilityStatus = "address_invalid"
+	StoreClosed    ServiceabilityStatus = "store_closed"
+)
+
+// Result of CheckServiceability
+type Serviceability struct {
+	Status ServiceabilityStatus
+	// Explanation from the API when the address is not serviceable
+	Reason string
+	// Quoted fee and dropoff estimate when the address is serviceable
+	Fee                  int
+	DropoffTimeEstimated time.Time
+	CheckedAt            time.Time
+}
+
+// API error codes classified directly. Codes not listed here are classified
+// from the field errors, then from keywords in the error text. Entries can be
+// added for codes seen in practice.
+var ServiceabilityCodes = map[string]ServiceabilityStatus{
+	"store_closed":            StoreClosed,
+	"store_not_open":          StoreClosed,
+	"distance_too_long":       OutOfRange,
+	"address_not_serviceable": OutOfRange,
+	"invalid_address":         AddressInvalid,
+}
+
+// Request fields whose validation errors decide the status. A dropoff
+// address error is an invalid address unless its text says it is out of
+// range, since the API reports both on the same field.
+var serviceabilityFields = map[string]ServiceabilityStatus{
+	"pickup_time":     StoreClosed,
+	"pickup_window":   StoreClosed,
+	"dropoff_address": AddressInvalid,
+}
+
+// Keywords in API error codes, messages and fields, checked in order. They
+// are only a fallback for errors without a known code or field, since
+// DoorDash may reword messages at any time.
+var serviceabilityRules = []struct {
+	status   ServiceabilityStatus
+	keywords []string
+}{
+	{StoreClosed, []string{"closed", "store_hours", "operating_hours", "not open"}},
+	{OutOfRange, []string{"distance", "range", "outside", "too far", "radius"}},
+	{AddressInvalid, []string{"address"}},
+}
+
+type serviceabilityCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*Serviceability
+}
+
+// WithServiceabilityTTL sets how long CheckServiceability outcomes are
+// cached. Zero disables caching.
+func WithServiceabilityTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.serviceability.ttl = ttl
+	}
+}
+
+// CheckServiceability reports whether dropoffAddress can be delivered to from
+// store, by requesting a quote that is never accepted. Each uncached check
+// creates a real quote on the account under a random external delivery ID
+// starting with "serviceability-"; quotes expire on their own but appear in
+// DoorDash reporting. Addresses that cannot be served are reported through
+// the result's Status rather than an error; errors are only returned when
+// the API could not be asked or gave an answer that could not be
+// classified. Outcomes are cached per store and normalized address, so
+// checks can run as the customer types.
+func (c *Client) CheckServiceability(ctx context.Context, store *StoreInfo, dropoffAddress string) (*Serviceability, error) {
+	key := store.ExternalBusinessID + "/" + store.ExternalStoreID + "/" + normalizeAddress(dropoffAddress)
+	now := time.Now()
+	if s := c.serviceability.get(key, now); s != nil {
+		return s, nil
+	}
+
+	if err := c.checkStore(ctx, store.ExternalBusinessID, store.ExternalStoreID); err != nil {
+		return nil, err
+	}
+	id, err := serviceabilityQuoteID()
+	if err != nil {
+		return nil, err
+	}
+	q := &NewQuote{
+		ExternalDeliveryID:       id,
+		PickupAddress:            store.Address,
+		PickupBusinessName:       store.Name,
+		PickupPhoneNumber:        store.PhoneNumber,
+		PickupExternalBusinessID: store.ExternalBusinessID,
+		PickupExternalStoreID:    store.ExternalStoreID,
+		DropoffAddress:           dropoffAddress,
+		// The customer's number is not known yet and does not affect
+		// serviceability
+		DropoffPhoneNumber: store.PhoneNumber,
+	}
+
+	quote := &DeliveryInfo{}
+	s := &Serviceability{CheckedAt: now}
+	err = c.makeRequestContext(ctx, "CheckServiceability", "POST", "drive/v2/quotes", nil, q, quote)
+	switch apiErr, ok := err.(*Error); {
+	case err == nil:
+		s.Status, s.Fee, s.DropoffTimeEstimated = Serviceable, quote.Fee, quote.DropoffTimeEstimated
+	case ok && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500:
+		s.Status, s.Reason = classifyServiceability(apiErr)
+		if s.Status == "" {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	c.serviceability.put(key, s)
+	return s, nil
+}
+
+// classifyServiceability classifies an API error by its code, then by the
+// fields it blames, and only then by keywords in its text
+func classifyServiceability(e *Error) (ServiceabilityStatus, string) {
+	if status, ok := ServiceabilityCodes[e.Code]; ok {
+		return status, strings.TrimSpace(e.Code + " " + e.Message)
+	}
+	for _, f := range e.FieldErrors {
+		status, ok := serviceabilityFields[f.Field]
+		if !ok {
+			continue
+		}
+		if status == AddressInvalid && matchServiceabilityKeywords(OutOfRange, f.Error) {
+			status = OutOfRange
+		}
+		return status, f.Field + " " + f.Error
+	}
+
+	texts := []string{e.Code + " " + e.Message}
+	for _, f := range e.FieldErrors {
+		texts = append(texts, f.Field+" "+f.Error)
+	}
+	for _, rule := range serviceabilityRules {
+		for _, text := range texts {
+			if matchServiceabilityKeywords(rule.status, text) {
+				return rule.status, strings.TrimSpace(text)
+			}
+		}
+	}
+	return "", ""
+}
+
+func matchServiceabilityKeywords(status ServiceabilityStatus, text string) bool {
+	lower := strings.ToLower(text)
+	for _, rule := range serviceabilityRules {
+		if rule.status != status {
+			continue
+		}
+		for _, kw := range rule.keywords {
+			if strings.Contains(lower, kw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeAddress makes differently typed forms of the same address share
+// a cache entry
+func normalizeAddress(address string) string {
+	address = strings.NewReplacer(",", " ", ".", " ", "#", " ").Replace(strings.ToLower(address))
+	return strings.Join(strings.Fields(address), " ")
+}
+
+func serviceabilityQuoteID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "serviceability-" + hex.EncodeToString(b), nil
+}
+
+func (sc *serviceabilityCache) get(key string, now time.Time) *Serviceability {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	s, ok := sc.entries[key]
+	if !ok {
+		return nil
+	}
+	if now.Sub(s.CheckedAt) >= sc.ttl {
+		delete(sc.entries, key)
+		return nil
+	}
+	cached := *s
+	return &cached
+}
+
+func (sc *serviceabilityCache) put(key string, s *Serviceability) {
+	if sc.ttl <= 0 {
+		return
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.entries == nil {
+		sc.entries = map[string]*Serviceability{}
+	}
+	// Drop expired entries so the cache does not grow without bound
+	for k, e := range sc.entries {
+		if s.CheckedAt.Sub(e.CheckedAt) >= sc.ttl {
+			delete(sc.entries, k)
+		}
+	}
+	cached := *s
+	sc.entries[key] = &cached
+}
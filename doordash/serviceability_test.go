@@ -0,0 +1,82 @@
+package doordash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCheckServiceability(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		body := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&body)
+		// Send response to be tested
+		switch address := body["dropoff_address"].(string); {
+		case strings.Contains(address, "Oakland"):
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"validation_error","message":"Validation Failed","field_errors":[{"field":"dropoff_address","error":"Allowed distance between addresses exceeded"}]}`))
+		case strings.Contains(address, "Nowhere"):
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"validation_error","message":"Validation Failed","field_errors":[{"field":"dropoff_address","error":"Unable to parse"}]}`))
+		case strings.Contains(address, "Late"):
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			rw.Write([]byte(`{"code":"store_closed","message":"Pickup store is closed"}`))
+		case strings.Contains(address, "Reworded"):
+			// Code and field decide, whatever the wording
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"validation_error","message":"Outside the allowed range","field_errors":[{"field":"dropoff_address","error":"Cannot geocode"}]}`))
+		case strings.Contains(address, "Early"):
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			rw.Write([]byte(`{"code":"store_not_open","message":"Pickup address is too far from opening"}`))
+		case strings.Contains(address, "Vague"):
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"request_rejected","message":"Dropoff is outside the delivery radius"}`))
+		case strings.Contains(address, "Broken"):
+			rw.WriteHeader(http.StatusInternalServerError)
+		default:
+			rw.Write(deliveryResponse)
+		}
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL + "/")
+	c := NewClient("token", WithHTTPClient(server.Client()))
+	c.BaseURL = url
+	store := &StoreInfo{ExternalBusinessID: "B-1", ExternalStoreID: "S-1", Address: "901 Market Street, San Francisco", PhoneNumber: "+16505555555"}
+	ctx := context.Background()
+
+	for address, want := range map[string]ServiceabilityStatus{
+		"1 Dr Carlton B Goodlett Pl, San Francisco": Serviceable,
+		"1 Broadway, Oakland":                       OutOfRange,
+		"1 Nowhere Road":                            AddressInvalid,
+		"1 Late Street":                             StoreClosed,
+		"1 Reworded Street":                         AddressInvalid,
+		"1 Early Street":                            StoreClosed,
+		"1 Vague Street":                            OutOfRange,
+	} {
+		s, err := c.CheckServiceability(ctx, store, address)
+		if err != nil {
+			t.Fatalf("%s: expected error to be nil, got %v", address, err)
+		}
+		if s.Status != want {
+			t.Errorf("%s: expected %s, got %s (%s)", address, want, s.Status, s.Reason)
+		}
+	}
+
+	before := requests
+	s, _ := c.CheckServiceability(ctx, store, "1 dr carlton b goodlett pl  san francisco.")
+	if requests != before || s.Status != Serviceable || s.Fee != 1900 {
+		t.Errorf("expected cached outcome for normalized address, got %+v after %d requests", s, requests-before)
+	}
+
+	if _, err := c.CheckServiceability(ctx, store, "1 Broken Street"); err == nil {
+		t.Error("expected server error to be returned, got nil")
+	}
+}
@@ -2,36 +2,67 @@
 package doordash
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/url"
 	"time"
 )
 
 // Object for creating a new stores
 type NewStore struct {
-	ExternalStoreID string `json:"external_store_id"`
-	Name            string `json:"name"`
-	PhoneNumber     string `json:"phone_number"`
-	Address         string `json:"address"`
+	ExternalStoreID           string           `json:"external_store_id"`
+	Name                      string           `json:"name"`
+	PhoneNumber               string           `json:"phone_number"`
+	Address                   string           `json:"address"`
+	Timezone                  string           `json:"timezone,omitempty"`
+	DefaultPickupInstructions string           `json:"default_pickup_instructions,omitempty"`
+	OperatingHours            []OperatingHours `json:"operating_hours,omitempty"`
+	SpecialHours              []SpecialHours   `json:"special_hours,omitempty"`
 }
 
 // Object for sending a store update
 type StoreUpdate struct {
-	Name        string `json:"name"`
-	PhoneNumber string `json:"phone_number"`
-	Address     string `json:"address"`
+	Name                      string           `json:"name"`
+	PhoneNumber               string           `json:"phone_number"`
+	Address                   string           `json:"address"`
+	Timezone                  string           `json:"timezone,omitempty"`
+	DefaultPickupInstructions string           `json:"default_pickup_instructions,omitempty"`
+	OperatingHours            []OperatingHours `json:"operating_hours,omitempty"`
+	SpecialHours              []SpecialHours   `json:"special_hours,omitempty"`
 }
 
 // Object containing response information for stores
 type StoreInfo struct {
-	Name               string    `json:"name"`
-	ExternalBusinessID string    `json:"external_business_id"`
-	ExternalStoreID    string    `json:"external_store_id"`
-	PhoneNumber        string    `json:"phone_number"`
-	Address            string    `json:"address"`
-	Status             string    `json:"status"`
-	IsTest             bool      `json:"is_test"`
-	CreatedAt          time.Time `json:"created_at"`
-	LastUpdatedAt      time.Time `json:"last_updated_at"`
+	Name                      string           `json:"name"`
+	ExternalBusinessID        string           `json:"external_business_id"`
+	ExternalStoreID           string           `json:"external_store_id"`
+	PhoneNumber               string           `json:"phone_number"`
+	Address                   string           `json:"address"`
+	Status                    string           `json:"status"`
+	IsTest                    bool             `json:"is_test"`
+	CreatedAt                 time.Time        `json:"created_at"`
+	LastUpdatedAt             time.Time        `json:"last_updated_at"`
+	Timezone                  string           `json:"timezone"`
+	DefaultPickupInstructions string           `json:"default_pickup_instructions"`
+	OperatingHours            []OperatingHours `json:"operating_hours"`
+	SpecialHours              []SpecialHours   `json:"special_hours"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (s *StoreInfo) UnmarshalJSON(data []byte) error {
+	type plain StoreInfo
+	return unmarshalWithExtra(data, (*plain)(s), &s.Extra)
+}
+
+func (s StoreInfo) MarshalJSON() ([]byte, error) {
+	type plain StoreInfo
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+func (s *StoreInfo) unknownFields() []string {
+	return extraKeys("", s.Extra)
 }
 
 // Object containing response information for mulitple stores
@@ -39,46 +70,102 @@ type StoreInfoList struct {
 	Result            []StoreInfo `json:"result"`
 	ContinuationToken string      `json:"continuation_token"`
 	ResultCount       int         `json:"result_count"`
+
+	// Fields not known to the SDK, kept so they survive re-marshalling
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (l *StoreInfoList) UnmarshalJSON(data []byte) error {
+	type plain StoreInfoList
+	return unmarshalWithExtra(data, (*plain)(l), &l.Extra)
+}
+
+func (l StoreInfoList) MarshalJSON() ([]byte, error) {
+	type plain StoreInfoList
+	return marshalWithExtra(plain(l), l.Extra)
+}
+
+func (l *StoreInfoList) unknownFields() []string {
+	fields := extraKeys("", l.Extra)
+	for i := range l.Result {
+		fields = append(fields, extraKeys(fmt.Sprintf("result[%d].", i), l.Result[i].Extra)...)
+	}
+	return fields
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/CreateStore
-func (c *Client) CreateStore(externalBusinessID string, body *NewStore) (*StoreInfo, error) {
+func (c *Client) CreateStore(externalBusinessID string, body *NewStore, opts ...CallOption) (*StoreInfo, error) {
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkBusiness(callContext(opts), externalBusinessID); err != nil {
+		return nil, err
+	}
+
 	res := &StoreInfo{}
-	if err := c.makeRequest("POST", ("/developer/v1/businesses/" + externalBusinessID + "/stores"), nil, body, res); err != nil {
+	if err := c.makeRequest("CreateStore", "POST", ("/developer/v1/businesses/" + externalBusinessID + "/stores"), nil, body, res, opts...); err != nil {
 		return nil, err
 	}
+	if err := c.checkWritten("store", res.ExternalStoreID, res.IsTest); err != nil {
+		return res, err
+	}
+
 	return res, nil
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/ListStore
-func (c *Client) ListStores(externalBusinessID string, activationStatus string, paginationToken string) (*StoreInfoList, error) {
+func (c *Client) ListStores(externalBusinessID string, activationStatus string, paginationToken string, opts ...CallOption) (*StoreInfoList, error) {
 	params := url.Values{
 		"activation_status": []string{activationStatus},
 		"pagination_token":  []string{paginationToken},
 	}
 
 	res := &StoreInfoList{}
-	if err := c.makeRequest("GET", ("/developer/v1/businesses/" + externalBusinessID + "/stores"), params, nil, res); err != nil {
+	if err := c.makeRequest("ListStores", "GET", ("/developer/v1/businesses/" + externalBusinessID + "/stores"), params, nil, res, opts...); err != nil {
 		return nil, err
 	}
 
+	for _, s := range res.Result {
+		if err := c.checkEnvironment("store", s.ExternalStoreID, s.IsTest); err != nil {
+			return nil, err
+		}
+	}
+
 	return res, nil
 }
 
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/GetStore
-func (c *Client) GetStore(externalBusinessID string, externalStoreID string) (*StoreInfo, error) {
+func (c *Client) GetStore(externalBusinessID string, externalStoreID string, opts ...CallOption) (*StoreInfo, error) {
 	res := &StoreInfo{}
-	if err := c.makeRequest("GET", ("/developer/v1/businesses/" + externalBusinessID + "/stores/" + externalStoreID), nil, nil, res); err != nil {
+	if err := c.makeRequest("GetStore", "GET", ("/developer/v1/businesses/" + externalBusinessID + "/stores/" + externalStoreID), nil, nil, res, opts...); err != nil {
+		return nil, err
+	}
+	if err := c.checkEnvironment("store", res.ExternalStoreID, res.IsTest); err != nil {
 		return nil, err
 	}
+
 	return res, nil
 }
 
+// UpdateStore updates a store. Hours are local to the store, so updates that
+// set OperatingHours or SpecialHours must also set Timezone.
+//
 // API Spec: https://developer.doordash.com/en-US/api/drive#tag/Business-and-Store/operation/UpdateStore
-func (c *Client) UpdateStore(externalBusinessID string, externalStoreID string, body *StoreUpdate) (*StoreInfo, error) {
+func (c *Client) UpdateStore(externalBusinessID string, externalStoreID string, body *StoreUpdate, opts ...CallOption) (*StoreInfo, error) {
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkStore(callContext(opts), externalBusinessID, externalStoreID); err != nil {
+		return nil, err
+	}
+
 	res := &StoreInfo{}
-	if err := c.makeRequest("PATCH", ("/developer/v1/businesses/" + externalBusinessID + "/stores/" + externalStoreID), nil, body, res); err != nil {
+	if err := c.makeRequest("UpdateStore", "PATCH", ("/developer/v1/businesses/" + externalBusinessID + "/stores/" + externalStoreID), nil, body, res, opts...); err != nil {
 		return nil, err
 	}
+	if err := c.checkWritten("store", res.ExternalStoreID, res.IsTest); err != nil {
+		return res, err
+	}
+
 	return res, nil
 }
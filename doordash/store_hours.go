@@ -0,0 +1,220 @@
+// Local validation of store operating hours
+package doordash
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	hoursLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+var weekdays = map[string]bool{
+	"monday":    true,
+	"tuesday":   true,
+	"wednesday": true,
+	"thursday":  true,
+	"friday":    true,
+	"saturday":  true,
+	"sunday":    true,
+}
+
+// Object describing when a store is open on a given day of the week.
+// Times are local to the store's timezone in 24-hour "HH:MM" format. An
+// EndTime before StartTime closes past midnight, on the following day.
+type OperatingHours struct {
+	DayOfWeek string `json:"day_of_week"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// Object overriding a store's regular hours on a specific date, e.g. a holiday.
+// Date is local to the store's timezone in "YYYY-MM-DD" format.
+type SpecialHours struct {
+	Date      string `json:"date"`
+	Closed    bool   `json:"closed"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// Validate checks the store's hours against its timezone before it is sent
+func (s *NewStore) Validate() error {
+	return ValidateStoreHours(s.Timezone, s.OperatingHours, s.SpecialHours)
+}
+
+// Validate checks the update's hours against its timezone before it is sent
+func (s *StoreUpdate) Validate() error {
+	return ValidateStoreHours(s.Timezone, s.OperatingHours, s.SpecialHours)
+}
+
+// ValidateStoreHours checks weekly and special hours in the given IANA
+// timezone, which is required when any hours are set
+func ValidateStoreHours(timezone string, hours []OperatingHours, special []SpecialHours) error {
+	if timezone == "" {
+		if len(hours) > 0 || len(special) > 0 {
+			return fmt.Errorf("timezone is required when setting store hours")
+		}
+		return nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %v", timezone, err)
+	}
+
+	for i, h := range hours {
+		if !weekdays[strings.ToLower(h.DayOfWeek)] {
+			return fmt.Errorf("operating_hours[%d]: invalid day_of_week %q", i, h.DayOfWeek)
+		}
+		if err := ValidateTimeRange(h.StartTime, h.EndTime); err != nil {
+			return fmt.Errorf("operating_hours[%d]: %v", i, err)
+		}
+	}
+	return ValidateSpecialHours(special, loc)
+}
+
+// ValidateSpecialHours checks special hours: valid unique dates, no times on
+// closed dates and a valid range otherwise. When loc is not nil, both ends
+// must also exist on their date in loc, e.g. not be skipped by a daylight
+// saving change.
+func ValidateSpecialHours(special []SpecialHours, loc *time.Location) error {
+	seen := map[string]bool{}
+	for i, h := range special {
+		date, err := time.Parse(dateLayout, h.Date)
+		if err != nil {
+			return fmt.Errorf("special_hours[%d]: invalid date %q", i, h.Date)
+		}
+		if seen[h.Date] {
+			return fmt.Errorf("special_hours[%d]: duplicate date %q", i, h.Date)
+		}
+		seen[h.Date] = true
+
+		if h.Closed {
+			if h.StartTime != "" || h.EndTime != "" {
+				return fmt.Errorf("special_hours[%d]: closed dates cannot have start_time or end_time", i)
+			}
+			continue
+		}
+		if err := ValidateTimeRange(h.StartTime, h.EndTime); err != nil {
+			return fmt.Errorf("special_hours[%d]: %v", i, err)
+		}
+		if loc == nil {
+			continue
+		}
+		endDate := date
+		if h.EndTime < h.StartTime {
+			endDate = date.AddDate(0, 0, 1)
+		}
+		if !localTimeExists(date, h.StartTime, loc) {
+			return fmt.Errorf("special_hours[%d]: %s does not exist on %s in %s", i, h.StartTime, h.Date, loc)
+		}
+		if !localTimeExists(endDate, h.EndTime, loc) {
+			return fmt.Errorf("special_hours[%d]: %s does not exist on %s in %s", i, h.EndTime, endDate.Format(dateLayout), loc)
+		}
+	}
+	return nil
+}
+
+// ValidateTimeRange checks an "HH:MM" range. The end may be before the start
+// for hours that run past midnight, but the range cannot be empty.
+func ValidateTimeRange(start string, end string) error {
+	s, err := time.Parse(hoursLayout, start)
+	if err != nil {
+		return fmt.Errorf("invalid start_time %q", start)
+	}
+	e, err := time.Parse(hoursLayout, end)
+	if err != nil {
+		return fmt.Errorf("invalid end_time %q", end)
+	}
+	if e.Equal(s) {
+		return fmt.Errorf("end_time %q must differ from start_time %q", end, start)
+	}
+	return nil
+}
+
+// OpenAt reports whether the store's hours have it open at t. Stores without
+// a timezone or hours are open. Special hours replace the weekly hours of
+// their date, and dates with neither are open all day when the store only
+// has special hours. Hours past midnight count until their end on the
+// following day.
+func (s *StoreInfo) OpenAt(t time.Time) (bool, error) {
+	if s.Timezone == "" || (len(s.OperatingHours) == 0 && len(s.SpecialHours) == 0) {
+		return true, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("invalid timezone %q: %v", s.Timezone, err)
+	}
+
+	local := t.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	// Yesterday's hours may run past midnight into today
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		ranges, allDay := s.hoursOn(day)
+		if allDay && day.Equal(today) {
+			return true, nil
+		}
+		for _, r := range ranges {
+			start, end, err := timeRangeOn(day, r[0], r[1])
+			if err != nil {
+				return false, err
+			}
+			if !local.Before(start) && local.Before(end) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// hoursOn returns the store's start and end times on day, or whether it is
+// open all day
+func (s *StoreInfo) hoursOn(day time.Time) ([][2]string, bool) {
+	date := day.Format(dateLayout)
+	for _, h := range s.SpecialHours {
+		if h.Date != date {
+			continue
+		}
+		if h.Closed {
+			return nil, false
+		}
+		return [][2]string{{h.StartTime, h.EndTime}}, false
+	}
+	if len(s.OperatingHours) == 0 {
+		return nil, true
+	}
+
+	var ranges [][2]string
+	weekday := strings.ToLower(day.Weekday().String())
+	for _, h := range s.OperatingHours {
+		if strings.ToLower(h.DayOfWeek) == weekday {
+			ranges = append(ranges, [2]string{h.StartTime, h.EndTime})
+		}
+	}
+	return ranges, false
+}
+
+// timeRangeOn places an "HH:MM" range on day, ending on the following day
+// when end is before start
+func timeRangeOn(day time.Time, start string, end string) (time.Time, time.Time, error) {
+	if err := ValidateTimeRange(start, end); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	s, _ := time.Parse(hoursLayout, start)
+	e, _ := time.Parse(hoursLayout, end)
+	endDay := day.Day()
+	if e.Before(s) {
+		endDay++
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), s.Hour(), s.Minute(), 0, 0, day.Location()),
+		time.Date(day.Year(), day.Month(), endDay, e.Hour(), e.Minute(), 0, 0, day.Location()), nil
+}
+
+func localTimeExists(date time.Time, clock string, loc *time.Location) bool {
+	t, _ := time.Parse(hoursLayout, clock)
+	local := time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	return local.Hour() == t.Hour() && local.Minute() == t.Minute()
+}
@@ -0,0 +1,139 @@
+package doordash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateStoreHours(t *testing.T) {
+	weekday := []OperatingHours{{DayOfWeek: "Monday", StartTime: "09:00", EndTime: "17:00"}}
+
+	tests := []struct {
+		name     string
+		timezone string
+		hours    []OperatingHours
+		special  []SpecialHours
+		wantErr  bool
+	}{
+		{name: "no hours", timezone: ""},
+		{name: "valid hours", timezone: "America/Los_Angeles", hours: weekday},
+		{name: "missing timezone", hours: weekday, wantErr: true},
+		{name: "unknown timezone", timezone: "Mars/Olympus_Mons", hours: weekday, wantErr: true},
+		{
+			name:     "invalid weekday",
+			timezone: "America/Los_Angeles",
+			hours:    []OperatingHours{{DayOfWeek: "someday", StartTime: "09:00", EndTime: "17:00"}},
+			wantErr:  true,
+		},
+		{
+			name:     "overnight",
+			timezone: "America/Los_Angeles",
+			hours:    []OperatingHours{{DayOfWeek: "friday", StartTime: "22:00", EndTime: "02:00"}},
+		},
+		{
+			name:     "empty range",
+			timezone: "America/Los_Angeles",
+			hours:    []OperatingHours{{DayOfWeek: "friday", StartTime: "09:00", EndTime: "09:00"}},
+			wantErr:  true,
+		},
+		{
+			name:     "malformed time",
+			timezone: "America/Los_Angeles",
+			hours:    []OperatingHours{{DayOfWeek: "friday", StartTime: "9am", EndTime: "17:00"}},
+			wantErr:  true,
+		},
+		{
+			name:     "holiday closure",
+			timezone: "America/Los_Angeles",
+			special:  []SpecialHours{{Date: "2022-12-25", Closed: true}},
+		},
+		{
+			name:     "closed with hours",
+			timezone: "America/Los_Angeles",
+			special:  []SpecialHours{{Date: "2022-12-25", Closed: true, StartTime: "09:00", EndTime: "12:00"}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate date",
+			timezone: "America/Los_Angeles",
+			special: []SpecialHours{
+				{Date: "2022-12-24", StartTime: "09:00", EndTime: "12:00"},
+				{Date: "2022-12-24", Closed: true},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "invalid date",
+			timezone: "America/Los_Angeles",
+			special:  []SpecialHours{{Date: "12/24/2022", Closed: true}},
+			wantErr:  true,
+		},
+		{
+			name:     "time skipped by daylight saving",
+			timezone: "America/Los_Angeles",
+			special:  []SpecialHours{{Date: "2023-03-12", StartTime: "02:30", EndTime: "09:00"}},
+			wantErr:  true,
+		},
+		{
+			name:     "overnight into a time skipped by daylight saving",
+			timezone: "America/Los_Angeles",
+			special:  []SpecialHours{{Date: "2023-03-11", StartTime: "22:00", EndTime: "02:30"}},
+			wantErr:  true,
+		},
+		{
+			name:     "same time in timezone without daylight saving",
+			timezone: "America/Phoenix",
+			special:  []SpecialHours{{Date: "2023-03-12", StartTime: "02:30", EndTime: "09:00"}},
+		},
+	}
+
+	for _, tt := range tests {
+		err := ValidateStoreHours(tt.timezone, tt.hours, tt.special)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestStoreOpenAt(t *testing.T) {
+	store := &StoreInfo{
+		Timezone: "America/Los_Angeles",
+		OperatingHours: []OperatingHours{
+			{DayOfWeek: "friday", StartTime: "11:00", EndTime: "14:00"},
+			{DayOfWeek: "friday", StartTime: "22:00", EndTime: "02:00"},
+		},
+		SpecialHours: []SpecialHours{
+			{Date: "2023-05-12", Closed: true},
+			{Date: "2023-05-19", StartTime: "09:00", EndTime: "10:00"},
+		},
+	}
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{name: "lunch", at: time.Date(2023, 5, 5, 12, 0, 0, 0, loc), want: true},
+		{name: "afternoon", at: time.Date(2023, 5, 5, 15, 0, 0, 0, loc)},
+		{name: "before midnight", at: time.Date(2023, 5, 5, 23, 30, 0, 0, loc), want: true},
+		{name: "after midnight", at: time.Date(2023, 5, 6, 1, 30, 0, 0, loc), want: true},
+		{name: "after closing", at: time.Date(2023, 5, 6, 2, 0, 0, 0, loc)},
+		{name: "other day", at: time.Date(2023, 5, 8, 12, 0, 0, 0, loc)},
+		{name: "closed date", at: time.Date(2023, 5, 12, 12, 0, 0, 0, loc)},
+		{name: "special hours", at: time.Date(2023, 5, 19, 9, 30, 0, 0, loc), want: true},
+		{name: "outside special hours", at: time.Date(2023, 5, 19, 12, 0, 0, 0, loc)},
+		{name: "other timezone", at: time.Date(2023, 5, 5, 19, 0, 0, 0, time.UTC), want: true},
+	}
+
+	for _, tc := range tests {
+		got, err := store.OpenAt(tc.at)
+		if err != nil || got != tc.want {
+			t.Errorf("%s: expected open to be %v, got %v, %v", tc.name, tc.want, got, err)
+		}
+	}
+
+	if open, err := (&StoreInfo{}).OpenAt(time.Now()); err != nil || !open {
+		t.Errorf("expected store without hours to be open, got %v, %v", open, err)
+	}
+}
@@ -19,7 +19,15 @@ var storeResponse = []byte(`{
 	"status": "active",
 	"is_test": false,
 	"created_at": "2022-04-25T17:21:43Z",
-	"last_updated_at": "2022-04-25T17:21:43Z"
+	"last_updated_at": "2022-04-25T17:21:43Z",
+	"timezone": "America/Los_Angeles",
+	"default_pickup_instructions": "Use the side entrance.",
+	"operating_hours": [
+	  {"day_of_week": "monday", "start_time": "09:00", "end_time": "17:00"}
+	],
+	"special_hours": [
+	  {"date": "2022-12-25", "closed": true}
+	]
   }`)
 
 var storeListResponse = []byte(`{
@@ -62,7 +70,7 @@ func TestCreateStore(t *testing.T) {
 	}
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.CreateStore(testID, payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -80,8 +88,8 @@ func TestListStores(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		// Test request parameters
 		reqURL := req.URL.String()
-		if reqURL != ("/developer/v1/businesses/" + testID + "/stores") {
-			t.Errorf("expected request URL to be /developer/v1/businesses/%s/stores, got %s", testID, reqURL)
+		if reqURL != ("/developer/v1/businesses/" + testID + "/stores?activation_status=active&pagination_token=token") {
+			t.Errorf("expected request URL to be /developer/v1/businesses/%s/stores?activation_status=active&pagination_token=token, got %s", testID, reqURL)
 		}
 		// Send response to be tested
 		rw.Write(storeListResponse)
@@ -90,7 +98,7 @@ func TestListStores(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.ListStores(testID, "active", "token")
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -119,7 +127,7 @@ func TestGetStore(t *testing.T) {
 	defer server.Close()
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.GetStore(testBID, testID)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -154,7 +162,7 @@ func TestUpdateStore(t *testing.T) {
 	}
 
 	url, _ := url.Parse(server.URL + "/")
-	client := &Client{url, "token", server.Client()}
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
 	got, err := client.UpdateStore(testBID, testID, payload)
 	if err != nil {
 		t.Errorf("expected error to be nil, got %v", err)
@@ -166,3 +174,36 @@ func TestUpdateStore(t *testing.T) {
 		t.Errorf("expected response to be %v, got %v", want, got)
 	}
 }
+
+func TestUpdateStoreHoursRequireTimezone(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		methods = append(methods, req.Method)
+		// Send response to be tested
+		rw.Write(storeResponse)
+	}))
+	// Close the server when test finishes
+	defer server.Close()
+
+	payload := &StoreUpdate{
+		Name:           "Neighborhood Deli",
+		OperatingHours: []OperatingHours{{DayOfWeek: "friday", StartTime: "22:00", EndTime: "02:00"}},
+	}
+
+	url, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: url, token: "token", client: server.Client()}
+	if _, err := client.UpdateStore("B-12345", "S-12345", payload); err == nil {
+		t.Error("expected error for hours without a timezone, got nil")
+	}
+	if len(methods) != 0 {
+		t.Errorf("expected no requests, got %v", methods)
+	}
+
+	payload.Timezone = "America/Los_Angeles"
+	if _, err := client.UpdateStore("B-12345", "S-12345", payload); err != nil {
+		t.Errorf("expected error to be nil, got %v", err)
+	}
+	if want := []string{"PATCH"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("expected requests %v, got %v", want, methods)
+	}
+}
@@ -0,0 +1,388 @@
+// Package sync reconciles a desired set of businesses and stores against the
+// live state returned by the DoorDash Drive API. Businesses missing from the
+// desired state are only deactivated when pruning is enabled; stores missing
+// from it are never deactivated or removed, and must be retired by hand.
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	ActionCreate     ActionKind = "create"
+	ActionUpdate     ActionKind = "update"
+	ActionDeactivate ActionKind = "deactivate"
+
+	inactive = "inactive"
+)
+
+type (
+	// Kind of change an Action makes
+	ActionKind string
+
+	// A business along with the stores it should have
+	DesiredBusiness struct {
+		Business doordash.NewBusiness
+		Stores   []doordash.NewStore
+	}
+
+	// A single change required to move the live state to the desired state.
+	// Exactly one of the payload fields is set, depending on Kind and whether
+	// the action targets a business or a store.
+	Action struct {
+		Kind               ActionKind
+		ExternalBusinessID string
+		ExternalStoreID    string // empty for business actions
+
+		NewBusiness    *doordash.NewBusiness
+		BusinessUpdate *doordash.BusinessUpdate
+		NewStore       *doordash.NewStore
+		StoreUpdate    *doordash.StoreUpdate
+	}
+
+	// Ordered set of actions computed by Reconciler.Plan
+	Plan struct {
+		Actions []Action
+	}
+
+	// Outcome of applying a single action
+	Result struct {
+		Action Action
+		Err    error
+	}
+
+	// Per-action outcomes of Reconciler.Apply
+	Report struct {
+		Results []Result
+	}
+
+	Options struct {
+		// Maximum number of API calls in flight while applying a plan.
+		// Defaults to 1.
+		Concurrency int
+		// Compute and return the plan without applying it
+		DryRun bool
+		// Deactivate live businesses missing from the desired state. Off by
+		// default so a partial desired state cannot deactivate the rest of
+		// the account.
+		Prune bool
+	}
+
+	Reconciler struct {
+		client *doordash.Client
+		opts   Options
+	}
+)
+
+func NewReconciler(client *doordash.Client, opts Options) *Reconciler {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	return &Reconciler{client: client, opts: opts}
+}
+
+func (a Action) String() string {
+	if a.ExternalStoreID == "" {
+		return fmt.Sprintf("%s business %s", a.Kind, a.ExternalBusinessID)
+	}
+	return fmt.Sprintf("%s store %s/%s", a.Kind, a.ExternalBusinessID, a.ExternalStoreID)
+}
+
+// Write prints the plan one action per line, e.g. for dry-run output
+func (p *Plan) Write(w io.Writer) error {
+	if len(p.Actions) == 0 {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	for _, a := range p.Actions {
+		if _, err := fmt.Fprintln(w, a.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Failed returns the results of actions that returned an error
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Sync plans the changes needed to reach the desired state and applies them
+// unless the reconciler is in dry-run mode, in which case the report is nil.
+func (r *Reconciler) Sync(ctx context.Context, desired []DesiredBusiness) (*Plan, *Report, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.opts.DryRun {
+		return plan, nil, nil
+	}
+	return plan, r.Apply(ctx, plan), nil
+}
+
+// Plan compares the desired businesses and stores with the live state.
+// Businesses missing from the desired set are deactivated if Options.Prune
+// is set. Stores have no activation status in the Drive API, so stores
+// missing from the desired set are left untouched.
+func (r *Reconciler) Plan(ctx context.Context, desired []DesiredBusiness) (*Plan, error) {
+	live, err := r.listBusinesses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	wanted := map[string]bool{}
+	for i := range desired {
+		d := &desired[i]
+		id := d.Business.ExternalBusinessID
+		if wanted[id] {
+			return nil, fmt.Errorf("duplicate business %q in desired state", id)
+		}
+		wanted[id] = true
+
+		current, exists := live[id]
+		if !exists {
+			plan.Actions = append(plan.Actions, Action{
+				Kind:               ActionCreate,
+				ExternalBusinessID: id,
+				NewBusiness:        &d.Business,
+			})
+		} else if update := businessUpdate(&d.Business, current); update != nil {
+			plan.Actions = append(plan.Actions, Action{
+				Kind:               ActionUpdate,
+				ExternalBusinessID: id,
+				BusinessUpdate:     update,
+			})
+		}
+
+		stores := map[string]*doordash.StoreInfo{}
+		if exists {
+			if stores, err = r.listStores(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+		storeActions, err := planStores(id, d.Stores, stores)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, storeActions...)
+	}
+	if !r.opts.Prune {
+		return plan, nil
+	}
+
+	var stale []string
+	for id, b := range live {
+		if !wanted[id] && b.ActivationStatus != inactive {
+			stale = append(stale, id)
+		}
+	}
+	sort.Strings(stale)
+	for _, id := range stale {
+		b := live[id]
+		plan.Actions = append(plan.Actions, Action{
+			Kind:               ActionDeactivate,
+			ExternalBusinessID: id,
+			BusinessUpdate: &doordash.BusinessUpdate{
+				Name:             b.Name,
+				Description:      b.Description,
+				ActivationStatus: inactive,
+			},
+		})
+	}
+
+	return plan, nil
+}
+
+// Apply executes a plan. Business actions run before store actions so new
+// stores always have a parent business; stores of a business that failed to
+// be created are reported as failed without being attempted. Once ctx is
+// cancelled, the remaining actions fail with its error.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) *Report {
+	var businesses, stores []int
+	for i, a := range plan.Actions {
+		if a.ExternalStoreID == "" {
+			businesses = append(businesses, i)
+		} else {
+			stores = append(stores, i)
+		}
+	}
+
+	report := &Report{Results: make([]Result, len(plan.Actions))}
+	r.run(ctx, plan, businesses, report, nil)
+
+	failed := map[string]bool{}
+	for _, i := range businesses {
+		res := report.Results[i]
+		if res.Err != nil && res.Action.Kind == ActionCreate {
+			failed[res.Action.ExternalBusinessID] = true
+		}
+	}
+	r.run(ctx, plan, stores, report, failed)
+
+	return report
+}
+
+func (r *Reconciler) run(ctx context.Context, plan *Plan, indexes []int, report *Report, failedBusinesses map[string]bool) {
+	sem := make(chan struct{}, r.opts.Concurrency)
+	done := make(chan struct{})
+	for _, i := range indexes {
+		go func(i int) {
+			sem <- struct{}{}
+			defer func() { <-sem; done <- struct{}{} }()
+
+			a := plan.Actions[i]
+			if failedBusinesses[a.ExternalBusinessID] {
+				report.Results[i] = Result{Action: a, Err: fmt.Errorf("skipped: business %s was not created", a.ExternalBusinessID)}
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				report.Results[i] = Result{Action: a, Err: err}
+				return
+			}
+			report.Results[i] = Result{Action: a, Err: r.apply(ctx, a)}
+		}(i)
+	}
+	for range indexes {
+		<-done
+	}
+}
+
+func (r *Reconciler) apply(ctx context.Context, a Action) error {
+	withCtx := doordash.WithContext(ctx)
+	var err error
+	switch {
+	case a.NewBusiness != nil:
+		_, err = r.client.CreateBusiness(a.NewBusiness, withCtx)
+	case a.BusinessUpdate != nil:
+		_, err = r.client.UpdateBusiness(a.ExternalBusinessID, a.BusinessUpdate, withCtx)
+	case a.NewStore != nil:
+		_, err = r.client.CreateStore(a.ExternalBusinessID, a.NewStore, withCtx)
+	case a.StoreUpdate != nil:
+		_, err = r.client.UpdateStore(a.ExternalBusinessID, a.ExternalStoreID, a.StoreUpdate, withCtx)
+	default:
+		err = fmt.Errorf("action %s has no payload", a)
+	}
+	return err
+}
+
+func (r *Reconciler) listBusinesses(ctx context.Context) (map[string]*doordash.BusinessInfo, error) {
+	live := map[string]*doordash.BusinessInfo{}
+	token := ""
+	for {
+		page, err := r.client.ListBusinesses("", token, doordash.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Result {
+			live[page.Result[i].ExternalBusinessID] = &page.Result[i]
+		}
+		if page.ContinuationToken == "" || page.ContinuationToken == token {
+			return live, nil
+		}
+		token = page.ContinuationToken
+	}
+}
+
+func (r *Reconciler) listStores(ctx context.Context, externalBusinessID string) (map[string]*doordash.StoreInfo, error) {
+	live := map[string]*doordash.StoreInfo{}
+	token := ""
+	for {
+		page, err := r.client.ListStores(externalBusinessID, "", token, doordash.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Result {
+			live[page.Result[i].ExternalStoreID] = &page.Result[i]
+		}
+		if page.ContinuationToken == "" || page.ContinuationToken == token {
+			return live, nil
+		}
+		token = page.ContinuationToken
+	}
+}
+
+func planStores(businessID string, desired []doordash.NewStore, live map[string]*doordash.StoreInfo) ([]Action, error) {
+	var actions []Action
+	seen := map[string]bool{}
+	for i := range desired {
+		s := &desired[i]
+		if seen[s.ExternalStoreID] {
+			return nil, fmt.Errorf("duplicate store %q in business %q", s.ExternalStoreID, businessID)
+		}
+		seen[s.ExternalStoreID] = true
+
+		current, exists := live[s.ExternalStoreID]
+		if !exists {
+			actions = append(actions, Action{
+				Kind:               ActionCreate,
+				ExternalBusinessID: businessID,
+				ExternalStoreID:    s.ExternalStoreID,
+				NewStore:           s,
+			})
+		} else if update := storeUpdate(s, current); update != nil {
+			actions = append(actions, Action{
+				Kind:               ActionUpdate,
+				ExternalBusinessID: businessID,
+				ExternalStoreID:    s.ExternalStoreID,
+				StoreUpdate:        update,
+			})
+		}
+	}
+	return actions, nil
+}
+
+// businessUpdate returns the update needed to bring current in line with
+// desired, or nil when they already match. An empty desired activation status
+// keeps the live one.
+func businessUpdate(desired *doordash.NewBusiness, current *doordash.BusinessInfo) *doordash.BusinessUpdate {
+	status := desired.ActivationStatus
+	if status == "" {
+		status = current.ActivationStatus
+	}
+	if desired.Name == current.Name && desired.Description == current.Description && status == current.ActivationStatus {
+		return nil
+	}
+	return &doordash.BusinessUpdate{
+		Name:             desired.Name,
+		Description:      desired.Description,
+		ActivationStatus: status,
+	}
+}
+
+// storeUpdate returns the update needed to bring current in line with desired,
+// or nil when they already match. Optional settings left empty in desired are
+// not compared, matching how they are omitted from the update request.
+func storeUpdate(desired *doordash.NewStore, current *doordash.StoreInfo) *doordash.StoreUpdate {
+	same := desired.Name == current.Name &&
+		desired.PhoneNumber == current.PhoneNumber &&
+		desired.Address == current.Address &&
+		(desired.Timezone == "" || desired.Timezone == current.Timezone) &&
+		(desired.DefaultPickupInstructions == "" || desired.DefaultPickupInstructions == current.DefaultPickupInstructions) &&
+		(len(desired.OperatingHours) == 0 || reflect.DeepEqual(desired.OperatingHours, current.OperatingHours)) &&
+		(len(desired.SpecialHours) == 0 || reflect.DeepEqual(desired.SpecialHours, current.SpecialHours))
+	if same {
+		return nil
+	}
+	return &doordash.StoreUpdate{
+		Name:                      desired.Name,
+		PhoneNumber:               desired.PhoneNumber,
+		Address:                   desired.Address,
+		Timezone:                  desired.Timezone,
+		DefaultPickupInstructions: desired.DefaultPickupInstructions,
+		OperatingHours:            desired.OperatingHours,
+		SpecialHours:              desired.SpecialHours,
+	}
+}
@@ -0,0 +1,218 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var businessListResponse = []byte(`{
+	"result": [
+	  {"external_business_id": "B-1", "name": "Deli", "description": "Sandwiches", "activation_status": "active"},
+	  {"external_business_id": "B-2", "name": "Old Deli", "description": "Closed", "activation_status": "active"}
+	],
+	"continuation_token": ""
+}`)
+
+var storeListResponse = []byte(`{
+	"result": [
+	  {"external_business_id": "B-1", "external_store_id": "S-1", "name": "Deli #1", "phone_number": "+12065551212", "address": "901 Market Street"}
+	],
+	"continuation_token": ""
+}`)
+
+func newTestServer(requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		*requests = append(*requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/developer/v1/businesses":
+			rw.Write(businessListResponse)
+		case req.Method == "GET" && req.URL.Path == "/developer/v1/businesses/B-1/stores":
+			rw.Write(storeListResponse)
+		case req.Method == "POST" && req.URL.Path == "/developer/v1/businesses":
+			rw.WriteHeader(http.StatusConflict)
+			rw.Write([]byte(`{"code": "duplicate_business", "message": "Business already exists"}`))
+		default:
+			rw.Write([]byte(`{}`))
+		}
+	}))
+}
+
+func newTestClient(server *httptest.Server) *doordash.Client {
+	client := doordash.NewClient("token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+var desired = []DesiredBusiness{
+	{
+		Business: doordash.NewBusiness{ExternalBusinessID: "B-1", Name: "Deli", Description: "Sandwiches and soups"},
+		Stores: []doordash.NewStore{
+			{ExternalStoreID: "S-1", Name: "Deli #1", PhoneNumber: "+12065551212", Address: "901 Market Street"},
+			{ExternalStoreID: "S-2", Name: "Deli #2", PhoneNumber: "+12065551212", Address: "1 Main Street"},
+		},
+	},
+	{
+		Business: doordash.NewBusiness{ExternalBusinessID: "B-3", Name: "Bakery"},
+		Stores: []doordash.NewStore{
+			{ExternalStoreID: "S-3", Name: "Bakery #1", PhoneNumber: "+12065551212", Address: "2 Main Street"},
+		},
+	},
+}
+
+func TestPlan(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	r := NewReconciler(newTestClient(server), Options{Prune: true})
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	var got []string
+	for _, a := range plan.Actions {
+		got = append(got, a.String())
+	}
+	want := []string{
+		"update business B-1",
+		"create store B-1/S-2",
+		"create business B-3",
+		"create store B-3/S-3",
+		"deactivate business B-2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected actions %v, got %v", want, got)
+	}
+
+	if status := plan.Actions[4].BusinessUpdate.ActivationStatus; status != "inactive" {
+		t.Errorf("expected deactivation to set status inactive, got %q", status)
+	}
+
+	buf := &bytes.Buffer{}
+	plan.Write(buf)
+	if got := buf.String(); got != strings.Join(want, "\n")+"\n" {
+		t.Errorf("unexpected plan output %q", got)
+	}
+}
+
+func TestPlanKeepsMissingStores(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	// S-1 exists but is no longer wanted
+	r := NewReconciler(newTestClient(server), Options{})
+	plan, err := r.Plan(context.Background(), []DesiredBusiness{{Business: doordash.NewBusiness{ExternalBusinessID: "B-1", Name: "Deli", Description: "Sandwiches"}}})
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	for _, a := range plan.Actions {
+		if a.ExternalStoreID != "" {
+			t.Errorf("expected stores to be left untouched, got %s", a)
+		}
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	r := NewReconciler(newTestClient(server), Options{DryRun: true})
+	plan, report, err := r.Sync(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if plan == nil || report != nil {
+		t.Errorf("expected a plan and no report, got %v and %v", plan, report)
+	}
+	for _, req := range requests {
+		if !strings.HasPrefix(req, "GET ") {
+			t.Errorf("expected dry run to only read, got %s", req)
+		}
+	}
+}
+
+func TestSyncApply(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	r := NewReconciler(newTestClient(server), Options{Concurrency: 1})
+	_, report, err := r.Sync(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	var failed []string
+	for _, res := range report.Failed() {
+		failed = append(failed, res.Action.String())
+	}
+	want := []string{"create business B-3", "create store B-3/S-3"}
+	if !reflect.DeepEqual(failed, want) {
+		t.Errorf("expected failed actions %v, got %v", want, failed)
+	}
+
+	if _, ok := report.Failed()[0].Err.(*doordash.Error); !ok {
+		t.Errorf("expected API error, got %T", report.Failed()[0].Err)
+	}
+
+	for _, req := range requests {
+		if req == "POST /developer/v1/businesses/B-3/stores" {
+			t.Error("expected store of a failed business to be skipped")
+		}
+	}
+}
+
+func TestPlanWithoutPrune(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	// B-2 is live but missing from the desired state
+	r := NewReconciler(newTestClient(server), Options{})
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	for _, a := range plan.Actions {
+		if a.Kind == ActionDeactivate {
+			t.Errorf("expected no deactivation without pruning, got %s", a)
+		}
+	}
+}
+
+func TestSyncCancelled(t *testing.T) {
+	var requests []string
+	server := newTestServer(&requests)
+	defer server.Close()
+
+	r := NewReconciler(newTestClient(server), Options{})
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requests = nil
+	report := r.Apply(ctx, plan)
+	if len(report.Failed()) != len(plan.Actions) || report.Failed()[0].Err != context.Canceled {
+		t.Errorf("expected every action to fail with the cancelled context, got %+v", report.Results)
+	}
+	if len(requests) != 0 {
+		t.Errorf("expected no requests after cancellation, got %v", requests)
+	}
+	if _, _, err := r.Sync(ctx, desired); err == nil {
+		t.Error("expected cancelled sync to fail, got nil")
+	}
+}
@@ -0,0 +1,47 @@
+package watchdog
+
+import "github.com/alext251/doordash-go-sdk/doordash/internal/jsonfile"
+
+// WatchStore persisted to a JSON file so watches survive restarts. The whole
+// file is rewritten atomically on every change.
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// OpenFileStore loads the watches stored at path, starting empty if the file
+// does not exist yet
+func OpenFileStore(path string) (*FileStore, error) {
+	f := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+	var watches []*Watch
+	if err := jsonfile.Load(path, &watches); err != nil {
+		return nil, err
+	}
+	for _, w := range watches {
+		f.put(w)
+	}
+	return f, nil
+}
+
+func (f *FileStore) Put(w *Watch) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	previous, existed := f.watches[w.ID]
+	f.put(w)
+
+	watches := make([]*Watch, 0, len(f.watches))
+	for _, w := range f.watches {
+		watches = append(watches, w)
+	}
+	return jsonfile.Commit(f.path, watches, func() {
+		for _, a := range w.Attempts {
+			delete(f.attempts, a.ExternalDeliveryID)
+		}
+		if existed {
+			f.put(previous)
+		} else {
+			delete(f.watches, w.ID)
+		}
+	})
+}
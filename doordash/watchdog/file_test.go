@@ -0,0 +1,39 @@
+package watchdog
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watches.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	wd := &Watchdog{Store: store, Now: func() time.Time { return t0 }}
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}, nil)
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-2"}, nil)
+	w, _ := store.Get("D-1")
+	w.Attempts = append(w.Attempts, Attempt{ExternalDeliveryID: "D-1-r1", ParentID: "D-1", StartedAt: t0})
+	store.Put(w)
+	w, _ = store.Get("D-2")
+	w.Status = StatusDelivered
+	store.Put(w)
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	active, err := reopened.Active()
+	if err != nil || len(active) != 1 || active[0].ID != "D-1" {
+		t.Fatalf("expected D-1 to be active after reopening, got %v, %v", active, err)
+	}
+	if w, err := reopened.Get("D-1-r1"); err != nil || w.ID != "D-1" {
+		t.Errorf("expected attempts to be found after reopening, got %+v, %v", w, err)
+	}
+}
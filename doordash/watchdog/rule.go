@@ -0,0 +1,74 @@
+package watchdog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+// Information available to a Rule
+type RuleContext struct {
+	Watch *Watch
+	// Latest snapshot of the current attempt
+	Delivery *doordash.DeliveryInfo
+	// How long the current attempt has been running
+	Age time.Duration
+	Now time.Time
+}
+
+// Outcome of a rule asking for a delivery to be redispatched
+type Decision struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// Rule decides whether the current attempt of a watch should be cancelled and
+// redispatched, returning nil to leave it alone. Custom rules can implement it
+// directly or use RuleFunc.
+type Rule interface {
+	Check(rc *RuleContext) *Decision
+}
+
+// Adapter to use an ordinary function as a Rule
+type RuleFunc func(rc *RuleContext) *Decision
+
+func (f RuleFunc) Check(rc *RuleContext) *Decision {
+	return f(rc)
+}
+
+// NoDasherAfter redispatches deliveries still waiting for a dasher, in the
+// created or confirmed status, once they are older than the given duration
+type NoDasherAfter time.Duration
+
+func (r NoDasherAfter) Check(rc *RuleContext) *Decision {
+	switch rc.Delivery.DeliveryStatus {
+	case "created", "confirmed":
+	default:
+		return nil
+	}
+	if rc.Age < time.Duration(r) {
+		return nil
+	}
+	return &Decision{
+		Rule:   "no_dasher",
+		Reason: fmt.Sprintf("no dasher after %v, maximum is %v", rc.Age.Round(time.Minute), time.Duration(r)),
+	}
+}
+
+// CancelledWithReason redispatches deliveries cancelled by DoorDash with one
+// of the given cancellation reasons, e.g. "dasher_not_available". Deliveries
+// cancelled for other reasons are left cancelled.
+type CancelledWithReason []string
+
+func (r CancelledWithReason) Check(rc *RuleContext) *Decision {
+	if rc.Delivery.DeliveryStatus != "cancelled" {
+		return nil
+	}
+	for _, reason := range r {
+		if rc.Delivery.CancellationReason == reason {
+			return &Decision{Rule: "cancelled", Reason: "cancelled by DoorDash: " + reason}
+		}
+	}
+	return nil
+}
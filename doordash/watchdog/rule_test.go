@@ -0,0 +1,40 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		status string
+		reason string
+		age    time.Duration
+		want   string
+	}{
+		{"waiting", NoDasherAfter(15 * time.Minute), "created", "", 10 * time.Minute, ""},
+		{"stuck", NoDasherAfter(15 * time.Minute), "confirmed", "", 15 * time.Minute, "no_dasher"},
+		{"dasher assigned", NoDasherAfter(15 * time.Minute), "enroute_to_pickup", "", time.Hour, ""},
+		{"retryable cancellation", CancelledWithReason{"dasher_not_available"}, "cancelled", "dasher_not_available", 0, "cancelled"},
+		{"other cancellation", CancelledWithReason{"dasher_not_available"}, "cancelled", "customer_request", 0, ""},
+		{"custom", RuleFunc(func(rc *RuleContext) *Decision {
+			return &Decision{Rule: "custom"}
+		}), "created", "", 0, "custom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.rule.Check(&RuleContext{Delivery: &doordash.DeliveryInfo{DeliveryStatus: tt.status, CancellationReason: tt.reason}, Age: tt.age})
+			got := ""
+			if d != nil {
+				got = d.Rule
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
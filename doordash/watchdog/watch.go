@@ -0,0 +1,139 @@
+package watchdog
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	StatusActive    WatchStatus = "active"
+	StatusDelivered WatchStatus = "delivered"
+	StatusCancelled WatchStatus = "cancelled"
+	// Redispatched MaxAttempts times without being delivered
+	StatusExhausted WatchStatus = "exhausted"
+)
+
+var ErrWatchNotFound = errors.New("watch not found")
+
+type (
+	WatchStatus string
+
+	// A delivery followed by the watchdog across all of its dispatch attempts
+	Watch struct {
+		// ExternalDeliveryID of the first attempt
+		ID string `json:"id"`
+		// Request used for the first attempt; redispatches copy it under a
+		// derived ExternalDeliveryID, without its schedule unless
+		// Watchdog.Prepare sets a new one
+		Delivery doordash.NewDelivery `json:"delivery"`
+		// Dispatch attempts, oldest first. The last one is current.
+		Attempts  []Attempt   `json:"attempts"`
+		Status    WatchStatus `json:"status"`
+		Error     string      `json:"error,omitempty"`
+		UpdatedAt time.Time   `json:"updated_at"`
+	}
+
+	// One delivery created for a watch
+	Attempt struct {
+		ExternalDeliveryID string `json:"external_delivery_id"`
+		// Attempt this one replaced, empty for the first
+		ParentID       string    `json:"parent_id,omitempty"`
+		StartedAt      time.Time `json:"started_at"`
+		DeliveryStatus string    `json:"delivery_status"`
+		// Set once the attempt is cancelled by the watchdog or DoorDash, or
+		// delivered
+		EndedAt time.Time `json:"ended_at,omitempty"`
+		// Rule and reason that ended the attempt, when the watchdog did
+		Rule   string `json:"rule,omitempty"`
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// Persistence for watches
+	WatchStore interface {
+		// Put inserts or replaces a watch
+		Put(w *Watch) error
+		// Get returns the watch owning the attempt with the given
+		// ExternalDeliveryID, or ErrWatchNotFound
+		Get(externalDeliveryID string) (*Watch, error)
+		// Active returns the watches still being followed, oldest first
+		Active() ([]*Watch, error)
+	}
+
+	// In-memory WatchStore, safe for concurrent use
+	MemoryStore struct {
+		mu      sync.RWMutex
+		watches map[string]*Watch
+		// Attempt ExternalDeliveryID to watch ID
+		attempts map[string]string
+	}
+)
+
+// Current returns the latest attempt
+func (w *Watch) Current() *Attempt {
+	return &w.Attempts[len(w.Attempts)-1]
+}
+
+func (w *Watch) attempt(externalDeliveryID string) *Attempt {
+	for i := range w.Attempts {
+		if w.Attempts[i].ExternalDeliveryID == externalDeliveryID {
+			return &w.Attempts[i]
+		}
+	}
+	return nil
+}
+
+func copyWatch(w *Watch) *Watch {
+	c := *w
+	c.Attempts = append([]Attempt(nil), w.Attempts...)
+	return &c
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{watches: map[string]*Watch{}, attempts: map[string]string{}}
+}
+
+func (m *MemoryStore) Put(w *Watch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(w)
+	return nil
+}
+
+func (m *MemoryStore) put(w *Watch) {
+	m.watches[w.ID] = copyWatch(w)
+	for _, a := range w.Attempts {
+		m.attempts[a.ExternalDeliveryID] = w.ID
+	}
+}
+
+func (m *MemoryStore) Get(externalDeliveryID string) (*Watch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	w, ok := m.watches[m.attempts[externalDeliveryID]]
+	if !ok {
+		return nil, ErrWatchNotFound
+	}
+	return copyWatch(w), nil
+}
+
+func (m *MemoryStore) Active() ([]*Watch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var active []*Watch
+	for _, w := range m.watches {
+		if w.Status == StatusActive {
+			active = append(active, copyWatch(w))
+		}
+	}
+	sort.Slice(active, func(i, k int) bool {
+		if active[i].Attempts[0].StartedAt.Equal(active[k].Attempts[0].StartedAt) {
+			return active[i].ID < active[k].ID
+		}
+		return active[i].Attempts[0].StartedAt.Before(active[k].Attempts[0].StartedAt)
+	})
+	return active, nil
+}
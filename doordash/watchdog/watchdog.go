@@ -0,0 +1,355 @@
+// Package watchdog follows active deliveries and redispatches the ones that
+// get stuck. Deliveries are checked against configurable rules, such as no
+// dasher being assigned after 15 minutes or DoorDash cancelling them for a
+// retryable reason; a matching delivery is cancelled if still active and
+// created again under a derived ExternalDeliveryID. Each watch keeps the
+// lineage of its attempts. Watches kept in a FileStore survive restarts.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+const (
+	defaultPollInterval = time.Minute
+	defaultMaxAttempts  = 3
+)
+
+type Watchdog struct {
+	Client *doordash.Client
+	Store  WatchStore
+	// Rules checked in order against the current attempt; the first one
+	// returning a decision redispatches the delivery
+	Rules []Rule
+	// How often Run polls active deliveries, defaults to a minute
+	PollInterval time.Duration
+	// Number of attempts, including the first, after which a stuck delivery
+	// is given up on, defaults to 3
+	MaxAttempts int
+	// Derives the ExternalDeliveryID of a redispatched attempt, numbered from
+	// 2. Defaults to the watch ID followed by "-r" and the redispatch count,
+	// e.g. "D-123-r1".
+	DeriveID func(w *Watch, attempt int) string
+	// Adjusts the request of a redispatched attempt, e.g. to schedule it
+	// again. The pickup and dropoff times and windows of the first attempt
+	// have passed by then, so they are cleared before Prepare is called and
+	// the attempt is dispatched as soon as possible without it. An error is
+	// handled like a failed API call.
+	Prepare func(w *Watch, d *doordash.NewDelivery) error
+
+	// Called after a new attempt is created, with the attempt it replaced
+	OnRedispatch func(w *Watch, previous *Attempt)
+	// Called when a stuck delivery has used up MaxAttempts. Its last attempt
+	// is left running, since no new attempt would replace it.
+	OnExhausted func(w *Watch)
+	// Called for every failed API call or store access. The watch is left as
+	// it was and the work is retried on the next Tick. w is nil when the
+	// active watches could not be read.
+	OnError func(w *Watch, err error)
+
+	// Clock, defaults to time.Now
+	Now func() time.Time
+
+	mu sync.Mutex
+	// Serializes webhook and polling updates of each watch
+	locks map[string]*watchLock
+}
+
+type watchLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Track starts following a delivery created from d. info is the response of
+// CreateDelivery, if available, and sets the initial status.
+func (wd *Watchdog) Track(d *doordash.NewDelivery, info *doordash.DeliveryInfo) error {
+	if d.ExternalDeliveryID == "" {
+		return fmt.Errorf("external delivery ID is required")
+	}
+	defer wd.lock(d.ExternalDeliveryID)()
+	if _, err := wd.Store.Get(d.ExternalDeliveryID); err == nil {
+		return fmt.Errorf("delivery %s is already tracked", d.ExternalDeliveryID)
+	} else if err != ErrWatchNotFound {
+		return err
+	}
+
+	status := "created"
+	if info != nil && info.DeliveryStatus != "" {
+		status = info.DeliveryStatus
+	}
+	now := wd.now()
+	return wd.Store.Put(&Watch{
+		ID:        d.ExternalDeliveryID,
+		Delivery:  *d,
+		Attempts:  []Attempt{{ExternalDeliveryID: d.ExternalDeliveryID, StartedAt: now, DeliveryStatus: status}},
+		Status:    StatusActive,
+		UpdatedAt: now,
+	})
+}
+
+// HandleEvent applies a delivery webhook to the watch owning it. Events for
+// untracked deliveries are ignored. It can be passed directly to
+// doordash.NewWebhookHandler.
+func (wd *Watchdog) HandleEvent(e *doordash.DeliveryEvent) error {
+	w, err := wd.Store.Get(e.ExternalDeliveryID)
+	if err == ErrWatchNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	defer wd.lock(w.ID)()
+	// Read again, a poll may have changed the watch in the meantime
+	if w, err = wd.Store.Get(w.ID); err != nil {
+		return err
+	}
+	return wd.update(context.Background(), w, &e.DeliveryInfo)
+}
+
+// Run polls active deliveries every PollInterval until ctx is cancelled.
+// Watches are read from the store on every pass, so with a FileStore
+// deliveries tracked before a restart are picked up again. Errors are
+// reported through OnError and do not stop Run.
+func (wd *Watchdog) Run(ctx context.Context) error {
+	interval := wd.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := wd.Tick(ctx); err != nil && ctx.Err() == nil {
+			wd.reportError(nil, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick fetches the status of every active delivery and applies the rules,
+// and retries redispatches that could not be completed earlier. Each watch
+// is locked only while it is processed, so webhooks for other deliveries are
+// not held up. Failures of a single watch are reported through OnError; Tick
+// returns an error only if the active watches cannot be read or ctx is
+// cancelled.
+func (wd *Watchdog) Tick(ctx context.Context) error {
+	watches, err := wd.Store.Active()
+	if err != nil {
+		return err
+	}
+	for _, w := range watches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := wd.tick(ctx, w.ID); err != nil {
+			wd.reportError(w, err)
+		}
+	}
+	return nil
+}
+
+// tick polls a single watch
+func (wd *Watchdog) tick(ctx context.Context, id string) error {
+	defer wd.lock(id)()
+	// Read again, a webhook may have changed the watch in the meantime
+	w, err := wd.Store.Get(id)
+	if err != nil {
+		return err
+	}
+	if w.Status != StatusActive {
+		return nil
+	}
+
+	current := w.Current()
+	if !current.EndedAt.IsZero() {
+		// Cancelled by a rule, but the new attempt was not created
+		return wd.redispatch(ctx, w)
+	}
+	d, err := wd.Client.GetDeliveryStatus(current.ExternalDeliveryID, doordash.WithContext(ctx))
+	if err != nil {
+		return wd.fail(w, err)
+	}
+	return wd.update(ctx, w, d)
+}
+
+// update records a delivery snapshot on its attempt and, if the attempt is
+// current, applies the rules
+func (wd *Watchdog) update(ctx context.Context, w *Watch, d *doordash.DeliveryInfo) error {
+	a := w.attempt(d.ExternalDeliveryID)
+	if a == nil || w.Status != StatusActive {
+		return nil
+	}
+	a.DeliveryStatus = d.DeliveryStatus
+	if a != w.Current() || !a.EndedAt.IsZero() {
+		// Late news about an attempt that was already replaced or cancelled
+		return wd.save(w)
+	}
+
+	now := wd.now()
+	switch d.DeliveryStatus {
+	case "delivered":
+		a.EndedAt = now
+		w.Status = StatusDelivered
+		return wd.save(w)
+	}
+
+	rc := &RuleContext{Watch: w, Delivery: d, Age: now.Sub(a.StartedAt), Now: now}
+	var decision *Decision
+	for _, r := range wd.Rules {
+		if decision = r.Check(rc); decision != nil {
+			break
+		}
+	}
+	if decision == nil {
+		if d.DeliveryStatus == "cancelled" {
+			a.EndedAt = now
+			w.Status = StatusCancelled
+		}
+		return wd.save(w)
+	}
+
+	if d.DeliveryStatus != "cancelled" {
+		if len(w.Attempts) >= wd.maxAttempts() {
+			return wd.exhaust(w)
+		}
+		if _, err := wd.Client.CancelDelivery(a.ExternalDeliveryID, doordash.WithContext(ctx)); err != nil {
+			return wd.fail(w, err)
+		}
+		a.DeliveryStatus = "cancelled"
+	}
+	a.EndedAt = now
+	a.Rule, a.Reason = decision.Rule, decision.Reason
+	return wd.redispatch(ctx, w)
+}
+
+// redispatch creates the next attempt of a watch whose current attempt has
+// ended. A delivery already created under the derived ID, e.g. by an earlier
+// try whose response was lost, is adopted rather than created twice.
+func (wd *Watchdog) redispatch(ctx context.Context, w *Watch) error {
+	if len(w.Attempts) >= wd.maxAttempts() {
+		return wd.exhaust(w)
+	}
+
+	previous := *w.Current()
+	d := w.Delivery
+	d.ExternalDeliveryID = wd.deriveID(w, len(w.Attempts)+1)
+	d.PickupTime, d.DropoffTime = time.Time{}, time.Time{}
+	d.PickupWindow, d.DropoffWindow = doordash.TimeWindow{}, doordash.TimeWindow{}
+	if wd.Prepare != nil {
+		if err := wd.Prepare(w, &d); err != nil {
+			return wd.fail(w, err)
+		}
+	}
+
+	info, err := wd.Client.GetDeliveryStatus(d.ExternalDeliveryID, doordash.WithContext(ctx))
+	if apiErr, ok := err.(*doordash.Error); ok && apiErr.StatusCode == http.StatusNotFound {
+		info, err = wd.Client.CreateDelivery(&d, doordash.WithContext(ctx))
+	}
+	if err != nil {
+		return wd.fail(w, err)
+	}
+
+	w.Attempts = append(w.Attempts, Attempt{
+		ExternalDeliveryID: d.ExternalDeliveryID,
+		ParentID:           previous.ExternalDeliveryID,
+		StartedAt:          wd.now(),
+		DeliveryStatus:     info.DeliveryStatus,
+	})
+	w.Error = ""
+	if err := wd.save(w); err != nil {
+		return err
+	}
+	if wd.OnRedispatch != nil {
+		wd.OnRedispatch(w, &previous)
+	}
+	return nil
+}
+
+func (wd *Watchdog) maxAttempts() int {
+	if wd.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return wd.MaxAttempts
+}
+
+// exhaust gives up on a watch that has no attempts left
+func (wd *Watchdog) exhaust(w *Watch) error {
+	w.Status = StatusExhausted
+	if err := wd.save(w); err != nil {
+		return err
+	}
+	if wd.OnExhausted != nil {
+		wd.OnExhausted(w)
+	}
+	return nil
+}
+
+func (wd *Watchdog) fail(w *Watch, apiErr error) error {
+	w.Error = apiErr.Error()
+	if err := wd.save(w); err != nil {
+		return err
+	}
+	wd.reportError(w, apiErr)
+	return nil
+}
+
+func (wd *Watchdog) reportError(w *Watch, err error) {
+	if wd.OnError != nil {
+		wd.OnError(w, err)
+	}
+}
+
+// lock locks the watch with the given ID and returns its unlock function
+func (wd *Watchdog) lock(id string) func() {
+	wd.mu.Lock()
+	if wd.locks == nil {
+		wd.locks = map[string]*watchLock{}
+	}
+	l, ok := wd.locks[id]
+	if !ok {
+		l = &watchLock{}
+		wd.locks[id] = l
+	}
+	l.refs++
+	wd.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		wd.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(wd.locks, id)
+		}
+		wd.mu.Unlock()
+	}
+}
+
+func (wd *Watchdog) save(w *Watch) error {
+	w.UpdatedAt = wd.now()
+	return wd.Store.Put(w)
+}
+
+func (wd *Watchdog) deriveID(w *Watch, attempt int) string {
+	if wd.DeriveID != nil {
+		return wd.DeriveID(w, attempt)
+	}
+	return fmt.Sprintf("%s-r%d", w.ID, attempt-1)
+}
+
+func (wd *Watchdog) now() time.Time {
+	if wd.Now != nil {
+		return wd.Now()
+	}
+	return time.Now()
+}
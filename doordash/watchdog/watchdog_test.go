@@ -0,0 +1,291 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash"
+)
+
+var t0 = time.Date(2022, 4, 25, 17, 0, 0, 0, time.UTC)
+
+// Fake Drive API keeping delivery statuses by ID
+type testAPI struct {
+	mu         sync.Mutex
+	statuses   map[string]string
+	reasons    map[string]string
+	calls      []string
+	failCreate bool
+	// Create the delivery but answer with an error, as if the response
+	// was lost
+	loseCreate bool
+}
+
+func newTestServer(api *testAPI) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		id := strings.TrimPrefix(req.URL.Path, "/drive/v2/deliveries/")
+		api.calls = append(api.calls, req.Method+" "+id)
+		switch req.Method {
+		case "POST":
+			d := &doordash.NewDelivery{}
+			json.NewDecoder(req.Body).Decode(d)
+			id = d.ExternalDeliveryID
+			api.calls[len(api.calls)-1] = "POST " + id
+			if api.failCreate {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			api.statuses[id] = "created"
+			if api.loseCreate {
+				rw.WriteHeader(http.StatusGatewayTimeout)
+				return
+			}
+		case "GET":
+			if _, ok := api.statuses[id]; !ok {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+		case "PUT":
+			api.statuses[id] = "cancelled"
+		}
+		json.NewEncoder(rw).Encode(&doordash.DeliveryInfo{ExternalDeliveryID: id, DeliveryStatus: api.statuses[id], CancellationReason: api.reasons[id]})
+	}))
+}
+
+func newTestWatchdog(t *testing.T, api *testAPI, now *time.Time) *Watchdog {
+	server := newTestServer(api)
+	t.Cleanup(server.Close)
+	client := doordash.NewClient("token")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return &Watchdog{
+		Client: client,
+		Store:  NewMemoryStore(),
+		Rules:  []Rule{NoDasherAfter(15 * time.Minute), CancelledWithReason{"dasher_not_available"}},
+		Now:    func() time.Time { return *now },
+	}
+}
+
+func TestWatchdogRedispatchesStuckDelivery(t *testing.T) {
+	ctx := context.Background()
+	api := &testAPI{statuses: map[string]string{"D-1": "created"}, reasons: map[string]string{}}
+	now := t0
+	wd := newTestWatchdog(t, api, &now)
+	var redispatched []string
+	wd.OnRedispatch = func(w *Watch, previous *Attempt) {
+		redispatched = append(redispatched, previous.ExternalDeliveryID+">"+w.Current().ExternalDeliveryID)
+	}
+
+	if err := wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1", OrderValue: 1999}, nil); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if err := wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}, nil); err == nil {
+		t.Errorf("expected error tracking a delivery twice")
+	}
+
+	// Still within the allowed time
+	now = t0.Add(10 * time.Minute)
+	if err := wd.Tick(ctx); err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+	if len(redispatched) != 0 {
+		t.Fatalf("expected no redispatch yet, got %v", redispatched)
+	}
+
+	now = t0.Add(16 * time.Minute)
+	wd.Tick(ctx)
+	if strings.Join(api.calls, ",") != "GET D-1,GET D-1,PUT D-1,GET D-1-r1,POST D-1-r1" {
+		t.Errorf("unexpected API calls %v", api.calls)
+	}
+	w, err := wd.Store.Get("D-1-r1")
+	if err != nil || w.ID != "D-1" || len(w.Attempts) != 2 || w.Status != StatusActive {
+		t.Fatalf("expected the new attempt on the same watch, got %+v, %v", w, err)
+	}
+	first, second := w.Attempts[0], w.Attempts[1]
+	if first.DeliveryStatus != "cancelled" || first.Rule != "no_dasher" || !first.EndedAt.Equal(now) {
+		t.Errorf("unexpected first attempt %+v", first)
+	}
+	if second.ParentID != "D-1" || second.DeliveryStatus != "created" || !second.StartedAt.Equal(now) {
+		t.Errorf("unexpected second attempt %+v", second)
+	}
+	if len(redispatched) != 1 || redispatched[0] != "D-1>D-1-r1" {
+		t.Errorf("unexpected redispatches %v", redispatched)
+	}
+
+	// DoorDash cancels the second attempt for a retryable reason
+	api.statuses["D-1-r1"], api.reasons["D-1-r1"] = "cancelled", "dasher_not_available"
+	now = now.Add(time.Minute)
+	wd.Tick(ctx)
+	w, _ = wd.Store.Get("D-1")
+	if len(w.Attempts) != 3 || w.Current().ExternalDeliveryID != "D-1-r2" || w.Attempts[1].Rule != "cancelled" {
+		t.Fatalf("expected redispatch after cancellation, got %+v", w)
+	}
+	if strings.Contains(strings.Join(api.calls, ","), "PUT D-1-r1") {
+		t.Errorf("expected an already cancelled delivery not to be cancelled again, got %v", api.calls)
+	}
+
+	// Out of attempts, so the last one keeps its chance of being delivered
+	var exhausted *Watch
+	wd.OnExhausted = func(w *Watch) { exhausted = w }
+	now = now.Add(time.Hour)
+	wd.Tick(ctx)
+	if exhausted == nil || exhausted.Status != StatusExhausted || api.statuses["D-1-r2"] == "cancelled" {
+		t.Errorf("expected watch to be exhausted, got %+v", exhausted)
+	}
+	if strings.Contains(strings.Join(api.calls, ","), "PUT D-1-r2") || !exhausted.Current().EndedAt.IsZero() {
+		t.Errorf("expected the last attempt to be left running, got %v", api.calls)
+	}
+	if active, _ := wd.Store.Active(); len(active) != 0 {
+		t.Errorf("expected no active watches, got %d", len(active))
+	}
+}
+
+func TestWatchdogWebhooks(t *testing.T) {
+	api := &testAPI{statuses: map[string]string{}, reasons: map[string]string{}}
+	now := t0
+	wd := newTestWatchdog(t, api, &now)
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}, &doordash.DeliveryInfo{DeliveryStatus: "created"})
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-2"}, nil)
+
+	handler := doordash.NewWebhookHandler(wd.HandleEvent)
+	send := func(payload string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhooks", strings.NewReader(payload)))
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body)
+		}
+	}
+
+	send(`{"event_name": "DASHER_CONFIRMED", "external_delivery_id": "D-1", "delivery_status": "enroute_to_pickup"}`)
+	send(`{"event_name": "DELIVERY_CANCELLED", "external_delivery_id": "D-2", "delivery_status": "cancelled", "cancellation_reason": "customer_request"}`)
+	send(`{"event_name": "DASHER_CONFIRMED", "external_delivery_id": "D-99", "delivery_status": "enroute_to_pickup"}`)
+
+	// A dasher is on the way, so the age no longer matters
+	now = t0.Add(time.Hour)
+	send(`{"event_name": "DELIVERY_STATUS_UPDATE", "external_delivery_id": "D-1", "delivery_status": "picked_up"}`)
+	w, _ := wd.Store.Get("D-1")
+	if w.Status != StatusActive || w.Current().DeliveryStatus != "picked_up" || len(w.Attempts) != 1 {
+		t.Errorf("unexpected watch %+v", w)
+	}
+	send(`{"event_name": "DASHER_DROPPED_OFF", "external_delivery_id": "D-1", "delivery_status": "delivered"}`)
+	if w, _ = wd.Store.Get("D-1"); w.Status != StatusDelivered {
+		t.Errorf("expected delivered watch, got %+v", w)
+	}
+	if w, _ = wd.Store.Get("D-2"); w.Status != StatusCancelled || w.Current().Rule != "" {
+		t.Errorf("expected non-retryable cancellation to be left alone, got %+v", w)
+	}
+	if len(api.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", api.calls)
+	}
+}
+
+func TestWatchdogRetriesFailedRedispatch(t *testing.T) {
+	ctx := context.Background()
+	api := &testAPI{statuses: map[string]string{"D-1": "confirmed"}, reasons: map[string]string{}, failCreate: true}
+	now := t0
+	wd := newTestWatchdog(t, api, &now)
+	var errs []error
+	wd.OnError = func(w *Watch, err error) { errs = append(errs, err) }
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}, nil)
+
+	now = t0.Add(20 * time.Minute)
+	wd.Tick(ctx)
+	w, _ := wd.Store.Get("D-1")
+	if len(errs) != 1 || len(w.Attempts) != 1 || w.Error == "" || w.Current().EndedAt.IsZero() {
+		t.Fatalf("expected failed redispatch to be recorded, got %+v, %v", w, errs)
+	}
+
+	api.failCreate = false
+	wd.Tick(ctx)
+	w, _ = wd.Store.Get("D-1")
+	if len(w.Attempts) != 2 || w.Error != "" || strings.Join(api.calls, ",") != "GET D-1,PUT D-1,GET D-1-r1,POST D-1-r1,GET D-1-r1,POST D-1-r1" {
+		t.Errorf("expected redispatch to be retried without polling, got %+v, calls %v", w, api.calls)
+	}
+}
+
+func TestWatchdogAdoptsLostRedispatch(t *testing.T) {
+	api := &testAPI{statuses: map[string]string{"D-1": "created"}, reasons: map[string]string{}, loseCreate: true}
+	now := t0
+	wd := newTestWatchdog(t, api, &now)
+	ctx := context.Background()
+	var prepared []string
+	wd.Prepare = func(w *Watch, d *doordash.NewDelivery) error {
+		if !d.PickupTime.IsZero() || !d.DropoffWindow.StartTime.IsZero() {
+			t.Errorf("expected schedule to be cleared, got %+v", d)
+		}
+		prepared = append(prepared, d.ExternalDeliveryID)
+		d.DropoffInstructions = "Redispatched"
+		return nil
+	}
+	scheduled := &doordash.NewDelivery{ExternalDeliveryID: "D-1", PickupTime: t0.Add(5 * time.Minute)}
+	scheduled.DropoffWindow.StartTime, scheduled.DropoffWindow.EndTime = t0.Add(30*time.Minute), t0.Add(45*time.Minute)
+	wd.Track(scheduled, nil)
+
+	// DoorDash creates D-1-r1, but the response is lost
+	now = t0.Add(16 * time.Minute)
+	wd.Tick(ctx)
+	if w, _ := wd.Store.Get("D-1"); len(w.Attempts) != 1 || w.Error == "" {
+		t.Fatalf("expected lost redispatch to be recorded as failed, got %+v", w)
+	}
+
+	api.loseCreate = false
+	wd.Tick(ctx)
+	w, _ := wd.Store.Get("D-1")
+	if len(w.Attempts) != 2 || w.Current().ExternalDeliveryID != "D-1-r1" || w.Current().DeliveryStatus != "created" {
+		t.Errorf("expected existing delivery to be adopted, got %+v", w)
+	}
+	if strings.Count(strings.Join(api.calls, ","), "POST") != 1 {
+		t.Errorf("expected the delivery to be created once, got calls %v", api.calls)
+	}
+	if len(prepared) != 2 || prepared[0] != "D-1-r1" || !w.Delivery.PickupTime.Equal(t0.Add(5*time.Minute)) {
+		t.Errorf("expected each redispatch to be prepared from the original request, got %v, %+v", prepared, w.Delivery)
+	}
+}
+
+// Store failing every write once failPut is set
+type failingStore struct {
+	*MemoryStore
+	failPut bool
+}
+
+func (s *failingStore) Put(w *Watch) error {
+	if s.failPut {
+		return errors.New("disk full")
+	}
+	return s.MemoryStore.Put(w)
+}
+
+func TestWatchdogReportsStoreErrors(t *testing.T) {
+	api := &testAPI{statuses: map[string]string{"D-1": "created", "D-2": "created"}, reasons: map[string]string{}}
+	now := t0
+	wd := newTestWatchdog(t, api, &now)
+	store := &failingStore{MemoryStore: NewMemoryStore()}
+	wd.Store = store
+	var failed []string
+	wd.OnError = func(w *Watch, err error) { failed = append(failed, w.ID+": "+err.Error()) }
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-1"}, nil)
+	wd.Track(&doordash.NewDelivery{ExternalDeliveryID: "D-2"}, nil)
+
+	store.failPut = true
+	if err := wd.Tick(context.Background()); err != nil {
+		t.Fatalf("expected store errors to be reported, not returned, got %v", err)
+	}
+	if strings.Join(failed, ",") != "D-1: disk full,D-2: disk full" {
+		t.Errorf("expected every watch to be polled and its error reported, got %v", failed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := wd.Tick(ctx); err != context.Canceled {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// API Doc: https://developer.doordash.com/en-US/docs/drive/reference/webhooks
+package doordash
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alext251/doordash-go-sdk/doordash/internal/webhook"
+)
+
+// Object containing a delivery status webhook sent by DoorDash. The delivery
+// fields are sent alongside the event name at the top level of the payload.
+type DeliveryEvent struct {
+	EventName string    `json:"event_name"`
+	CreatedAt time.Time `json:"created_at"`
+	DeliveryInfo
+}
+
+// Event header fields, decoded separately because DeliveryInfo's JSON
+// methods would otherwise be promoted and skip them
+type deliveryEventHeader struct {
+	EventName string    `json:"event_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *DeliveryEvent) UnmarshalJSON(data []byte) error {
+	header := deliveryEventHeader{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &e.DeliveryInfo); err != nil {
+		return err
+	}
+	e.EventName, e.CreatedAt = header.EventName, header.CreatedAt
+	delete(e.Extra, "event_name")
+	delete(e.Extra, "created_at")
+	if len(e.Extra) == 0 {
+		e.Extra = nil
+	}
+	return nil
+}
+
+func (e DeliveryEvent) MarshalJSON() ([]byte, error) {
+	header, err := json.Marshal(deliveryEventHeader{EventName: e.EventName, CreatedAt: e.CreatedAt})
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := json.Marshal(e.DeliveryInfo)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(header[:len(header)-1], ','), delivery[1:]...), nil
+}
+
+// ParseDeliveryEvent decodes a delivery webhook payload
+func ParseDeliveryEvent(r io.Reader) (*DeliveryEvent, error) {
+	e := &DeliveryEvent{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewWebhookHandler returns an http.Handler that decodes delivery webhooks and
+// passes them to fn. Malformed payloads are answered with 400 and errors
+// returned by fn with 500 so DoorDash retries the delivery of the event.
+func NewWebhookHandler(fn func(*DeliveryEvent) error) http.Handler {
+	return webhook.Handler(func(body io.Reader) error {
+		e, err := ParseDeliveryEvent(body)
+		if err != nil {
+			return webhook.Malformed(err)
+		}
+		return fn(e)
+	})
+}
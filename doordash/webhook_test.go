@@ -0,0 +1,66 @@
+package doordash
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var deliveryEvent = []byte(`{
+	"event_name": "DASHER_CONFIRMED",
+	"created_at": "2018-08-22T17:20:28Z",
+	"external_delivery_id": "D-12345",
+	"delivery_status": "enroute_to_pickup",
+	"fee": 1900
+}`)
+
+func TestParseDeliveryEvent(t *testing.T) {
+	got, err := ParseDeliveryEvent(bytes.NewReader(deliveryEvent))
+	if err != nil {
+		t.Fatalf("expected error to be nil, got %v", err)
+	}
+
+	timeStamp, _ := time.Parse(time.RFC3339, "2018-08-22T17:20:28Z")
+	if got.EventName != "DASHER_CONFIRMED" || !got.CreatedAt.Equal(timeStamp) {
+		t.Errorf("unexpected event header %q at %v", got.EventName, got.CreatedAt)
+	}
+	if got.ExternalDeliveryID != "D-12345" || got.DeliveryStatus != "enroute_to_pickup" || got.Fee != 1900 {
+		t.Errorf("unexpected delivery %+v", got.DeliveryInfo)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var received *DeliveryEvent
+	var handlerErr error
+	handler := NewWebhookHandler(func(e *DeliveryEvent) error {
+		received = e
+		return handlerErr
+	})
+
+	tests := []struct {
+		method string
+		body   []byte
+		err    error
+		want   int
+	}{
+		{method: "POST", body: deliveryEvent, want: http.StatusOK},
+		{method: "GET", want: http.StatusMethodNotAllowed},
+		{method: "POST", body: []byte(`{`), want: http.StatusBadRequest},
+		{method: "POST", body: deliveryEvent, err: errors.New("database down"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		handlerErr = tt.err
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/webhooks", bytes.NewReader(tt.body)))
+		if rec.Code != tt.want {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.body, tt.want, rec.Code)
+		}
+	}
+
+	if received == nil || received.ExternalDeliveryID != "D-12345" {
+		t.Errorf("expected handler to receive the event, got %v", received)
+	}
+}